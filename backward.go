@@ -3,47 +3,115 @@ package linescanner
 import (
 	"bytes"
 	"io"
+	"iter"
 )
 
 type backward struct {
-	reader io.ReaderAt
+	reader     io.ReaderAt
+	prefetcher *prefetcher
 
-	maxChunkSize int
-	chunk        []byte
+	maxChunkSize      int
+	adaptiveChunkSize bool
+	readSize          int
+	chunk             []byte
+	stats             Stats
+	pool              BufferPool
 
-	maxBufferSize int
-	buffer        []byte
+	maxBufferSize  int
+	storage        []byte
+	buffer         []byte
+	bufferStartPos int
+	searchedSize   int
 
-	readerPos        int
-	readerLineEndPos int
+	delimiter          []byte
+	keepCarriageReturn bool
 
-	err error
+	longLinePolicy LongLinePolicy
+	longLine       bool
+	longLineInfo   LineInfo
+	truncated      []byte
+
+	readerPos        int64
+	readerLineEndPos int64
+	// leadingLine is set once the delimiter at offset 0 is removed, which leaves the empty first line
+	leadingLine bool
+
+	lineTerminated      bool
+	lineTerminatedKnown bool
+
+	line   []byte
+	info   LineInfo
+	noLine bool
+	err    error
+}
+
+func NewBackward(reader io.ReaderAt, position int, opts ...Option) *backward {
+	return NewBackward64(reader, int64(position), opts...)
+}
+
+func NewBackward64(reader io.ReaderAt, position int64, opts ...Option) *backward {
+	b, err := newBackward(reader, position, opts)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TryNewBackward(reader io.ReaderAt, position int, opts ...Option) (*backward, error) {
+	return TryNewBackward64(reader, int64(position), opts...)
 }
 
-func NewBackward(reader io.ReaderAt, position int) *backward {
-	return NewBackwardWithSize(reader, position, defaultMaxChunkSize, defaultMaxBufferSize)
+func TryNewBackward64(reader io.ReaderAt, position int64, opts ...Option) (*backward, error) {
+	b, err := newBackward(reader, position, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePosition(reader, position); err != nil {
+		return nil, err
+	}
+	return b, nil
 }
 
 func NewBackwardWithSize(reader io.ReaderAt, position, maxChunkSize int, maxBufferSize int) *backward {
+	return NewBackward(reader, position, WithChunkSize(maxChunkSize), WithMaxBufferSize(maxBufferSize))
+}
+
+func NewBackwardWithDelimiter(reader io.ReaderAt, position int, delimiter []byte) *backward {
+	return NewBackward(reader, position, WithDelimiter(delimiter))
+}
+
+func newBackward(reader io.ReaderAt, position int64, opts []Option) (*backward, error) {
 	if reader == nil {
-		panic(ErrNilReader)
+		return nil, ErrNilReader
 	}
-	if maxChunkSize <= 0 {
-		panic(ErrInvalidMaxChunkSize)
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
 	}
-	if maxBufferSize <= 0 {
-		panic(ErrInvalidMaxBufferSize)
+	if err := o.validateReads(); err != nil {
+		return nil, err
 	}
-	if maxChunkSize > maxBufferSize {
-		panic(ErrGreaterBufferSize)
+	if o.split != nil {
+		return nil, ErrSplitFuncUnsupported
 	}
-	return &backward{
-		reader:           reader,
-		maxChunkSize:     maxChunkSize,
-		maxBufferSize:    maxBufferSize,
-		readerPos:        position,
-		readerLineEndPos: position,
+	b := &backward{
+		reader:             reader,
+		maxChunkSize:       o.maxChunkSize,
+		adaptiveChunkSize:  o.adaptiveChunkSize,
+		readSize:           o.minChunkSize,
+		pool:               o.bufferPool,
+		maxBufferSize:      o.maxBufferSize,
+		delimiter:          o.delimiter,
+		keepCarriageReturn: !o.trimCarriageReturn(),
+		longLinePolicy:     o.longLinePolicy,
+		readerPos:          position,
+		readerLineEndPos:   position,
 	}
+	if o.prefetch > 0 {
+		b.prefetcher = newPrefetcher(reader, Backward, o.maxChunkSize, o.adaptiveChunkSize, o.prefetch)
+		b.reader = b.prefetcher
+	}
+	return b, nil
 }
 
 func (b *backward) endOfFile() bool {
@@ -51,16 +119,33 @@ func (b *backward) endOfFile() bool {
 }
 
 func (b *backward) endOfScan() bool {
-	return b.endOfFile() && b.readerLineEndPos <= 0
+	return b.endOfFile() && b.readerLineEndPos <= 0 && !b.longLine
+}
+
+func (b *backward) chunkSize() int {
+	readSize := b.maxChunkSize
+	if b.adaptiveChunkSize {
+		readSize = b.readSize
+	}
+	chunkSize := minInt64(b.readerPos, int64(readSize))
+	if b.longLinePolicy != LongLineError {
+		chunkSize = minInt64(chunkSize, int64(b.maxBufferSize-len(b.buffer)))
+	}
+	return int(chunkSize)
 }
 
 func (b *backward) allocateChunk() error {
-	chunkSize := minInt(b.readerPos, b.maxChunkSize)
-	if b.chunk == nil {
-		b.chunk = make([]byte, chunkSize)
+	chunkSize := b.chunkSize()
+	if cap(b.chunk) < chunkSize {
+		release(b.pool, b.chunk)
+		b.chunk = allocate(b.pool, chunkSize)
 	}
 	b.chunk = b.chunk[:chunkSize]
-	n, err := b.reader.ReadAt(b.chunk, int64(b.readerPos-chunkSize))
+	n, err := b.reader.ReadAt(b.chunk, b.readerPos-int64(chunkSize))
+	b.stats.add(chunkSize, n)
+	if b.adaptiveChunkSize {
+		b.readSize = growChunkSize(b.readSize, b.maxChunkSize)
+	}
 	if err != nil {
 		if err == io.EOF {
 			return ErrInvalidPosition
@@ -70,76 +155,343 @@ func (b *backward) allocateChunk() error {
 	if n != chunkSize {
 		return ErrReadFailure
 	}
-	b.readerPos -= chunkSize
+	b.readerPos -= int64(chunkSize)
 	return nil
 }
 
+// allocateBuffer prepends the chunk to the buffer, which is filled from the end of its storage toward
+// the front, so that reading a long line copies each byte a constant number of times.
 func (b *backward) allocateBuffer() error {
 	chunkSize := len(b.chunk)
 	bufferSize := len(b.buffer)
 	if chunkSize+bufferSize > b.maxBufferSize {
 		return ErrBufferOverflow
 	}
-	if chunkSize+bufferSize > cap(b.buffer) {
-		expandedBuffer := make([]byte, 0, chunkSize+bufferSize)
-		expandedBuffer = append(expandedBuffer, b.chunk...)
-		expandedBuffer = append(expandedBuffer, b.buffer...)
-		b.buffer = expandedBuffer
-	} else {
-		prevBufferSize := bufferSize
-		b.buffer = b.buffer[:chunkSize+prevBufferSize]
-		copy(b.buffer[chunkSize:], b.buffer[:prevBufferSize])
-		copy(b.buffer, b.chunk)
+	if chunkSize > b.bufferStartPos {
+		storage := b.storage
+		if chunkSize+bufferSize > cap(storage) {
+			storage = allocate(b.pool, minInt(maxInt(2*cap(storage), chunkSize+bufferSize), b.maxBufferSize))
+		}
+		storage = storage[:cap(storage)]
+		b.bufferStartPos = len(storage) - bufferSize
+		copy(storage[b.bufferStartPos:], b.buffer)
+		if cap(storage) > cap(b.storage) {
+			release(b.pool, b.storage)
+		}
+		b.storage = storage
 	}
+	b.bufferStartPos -= chunkSize
+	copy(b.storage[b.bufferStartPos:], b.chunk)
+	b.buffer = b.storage[b.bufferStartPos : b.bufferStartPos+chunkSize+bufferSize]
 	return nil
 }
 
-func (b *backward) removeLineFromBuffer(lineFeedStartPos int) string {
-	lineWithCR := b.buffer[lineFeedStartPos+1:]
-	line := removeCarriageReturn(lineWithCR)
-	b.buffer = b.buffer[:maxInt(lineFeedStartPos, 0)]
-	b.readerLineEndPos -= len(lineWithCR)
-	if lineFeedStartPos >= 0 {
-		b.readerLineEndPos--
+// lastDelimiter searches the buffer for its last delimiter, skipping the end of the buffer that an
+// earlier search has already found to contain none.
+func (b *backward) lastDelimiter() int {
+	searchEnd := minInt(len(b.buffer)-b.searchedSize+len(b.delimiter)-1, len(b.buffer))
+	delimiterStartPos := bytes.LastIndex(b.buffer[:searchEnd], b.delimiter)
+	if delimiterStartPos < 0 {
+		b.searchedSize = len(b.buffer)
 	}
+	return delimiterStartPos
+}
+
+func (b *backward) delimiterAt(position int64) bool {
+	delimiter := make([]byte, len(b.delimiter))
+	n, _ := b.reader.ReadAt(delimiter, position)
+	return n == len(delimiter) && bytes.Equal(delimiter, b.delimiter)
+}
+
+func (b *backward) removeBytesFromBuffer(startPos int) []byte {
+	lineWithCR := b.buffer[startPos:]
+	b.readerLineEndPos -= int64(len(lineWithCR))
+	b.info = LineInfo{
+		Start: b.readerLineEndPos,
+		End:   b.readerLineEndPos + int64(len(lineWithCR)),
+	}
+	if b.longLine {
+		return lineWithCR
+	}
+	b.info.Terminated = b.lineTerminated
+	if b.keepCarriageReturn {
+		return lineWithCR
+	}
+	line := trimCarriageReturn(lineWithCR)
+	if len(line) < len(lineWithCR) {
+		b.info.End--
+		b.info.CarriageReturn = true
+	}
+	return line
+}
+
+func (b *backward) removeLineFromBuffer(delimiterStartPos int) []byte {
+	lineStartPos := 0
+	if delimiterStartPos >= 0 {
+		lineStartPos = delimiterStartPos + len(b.delimiter)
+	}
+	line := b.removeBytesFromBuffer(lineStartPos)
+	b.buffer = b.buffer[:maxInt(delimiterStartPos, 0)]
+	b.searchedSize = 0
+	if delimiterStartPos >= 0 {
+		b.readerLineEndPos -= int64(len(b.delimiter))
+		b.leadingLine = b.readerLineEndPos == 0
+	}
+	b.lineTerminated = delimiterStartPos >= 0
 	return line
 }
 
+// maxFragmentSize leaves room for a delimiter and a carriage return, like forward, so that a long line
+// is cut to the same size in both directions.
+func (b *backward) maxFragmentSize(bufferSize int) int {
+	fragmentSize := bufferSize - len(b.delimiter) + 1
+	if !b.keepCarriageReturn {
+		fragmentSize--
+	}
+	return fragmentSize
+}
+
+func (b *backward) removeFragmentFromBuffer() []byte {
+	fragmentSize := minInt(b.maxFragmentSize(b.maxBufferSize), len(b.buffer))
+	if fragmentSize <= 0 {
+		fragmentSize = len(b.buffer)
+	}
+	fragmentStartPos := len(b.buffer) - fragmentSize
+	fragment := b.removeBytesFromBuffer(fragmentStartPos)
+	if !b.longLine {
+		b.longLine = true
+		b.longLineInfo = b.info
+	}
+	b.buffer = b.buffer[:fragmentStartPos]
+	b.searchedSize = len(b.buffer)
+	return fragment
+}
+
+func (b *backward) bufferFull() bool {
+	return b.longLinePolicy != LongLineError && len(b.buffer) >= b.maxBufferSize
+}
+
+// longFirstLine reports whether the first line, which has no delimiter before it, would not fit in the
+// buffer with one, like forward's last line.
+func (b *backward) longFirstLine() bool {
+	return b.longLinePolicy != LongLineError && !b.longLine && len(b.buffer)+len(b.delimiter) > b.maxBufferSize
+}
+
+func (b *backward) handleLongLine() ([]byte, bool) {
+	fragment := b.removeFragmentFromBuffer()
+	switch b.longLinePolicy {
+	case LongLineFragment:
+		b.info.Continued = true
+		return fragment, true
+	case LongLineTruncate:
+		b.truncated = append(b.truncated[:0], fragment...)
+	}
+	return nil, false
+}
+
+func (b *backward) completeLine(line []byte) ([]byte, bool) {
+	if !b.longLine {
+		return line, true
+	}
+	b.longLine = false
+	switch b.longLinePolicy {
+	case LongLineTruncate:
+		line = line[:minInt(len(line), b.maxFragmentSize(b.maxBufferSize))]
+		size := minInt(len(b.truncated), b.maxFragmentSize(b.maxBufferSize)-len(line))
+		truncated := append(b.truncated[:size], line...)
+		copy(truncated[len(line):], truncated[:size])
+		copy(truncated, line)
+		b.truncated = truncated
+		b.info.End = b.longLineInfo.End
+		b.info.CarriageReturn = b.longLineInfo.CarriageReturn
+		b.info.Terminated = b.longLineInfo.Terminated
+		b.info.Truncated = true
+		return truncated, true
+	case LongLineSkip:
+		return nil, false
+	}
+	return line, true
+}
+
+func (b *backward) newScanError(op string, chunkOffset int64, err error) *ScanError {
+	return &ScanError{
+		Op:          op,
+		Direction:   Backward,
+		Offset:      b.readerLineEndPos,
+		ChunkOffset: chunkOffset,
+		Err:         err,
+	}
+}
+
 func (b *backward) read() error {
+	chunkOffset := b.readerPos - int64(b.chunkSize())
 	if err := b.allocateChunk(); err != nil {
-		return err
+		return b.newScanError(opRead, chunkOffset, err)
 	}
 	if err := b.allocateBuffer(); err != nil {
-		return err
+		return b.newScanError(opBuffer, chunkOffset, err)
 	}
 	return nil
 }
 
 func (b *backward) Line() (string, error) {
+	line, err := b.LineBytes()
+	return string(line), err
+}
+
+func (b *backward) LineBytes() ([]byte, error) {
 	if b.err != nil {
-		return "", b.err
+		return nil, b.err
 	}
-	if b.endOfScan() {
-		return "", io.EOF
+	b.info = LineInfo{}
+	b.noLine = false
+	if !b.lineTerminatedKnown && !b.endOfScan() {
+		b.lineTerminated = b.delimiterAt(b.readerLineEndPos)
+		b.lineTerminatedKnown = true
 	}
 	for {
-		lineFeedStartPos := bytes.LastIndexByte(b.buffer, '\n')
-		if lineFeedStartPos >= 0 {
-			return b.removeLineFromBuffer(lineFeedStartPos), nil
+		if b.leadingLine {
+			b.leadingLine = false
+			b.info = LineInfo{Terminated: true}
+			return nil, io.EOF
+		}
+		if b.endOfScan() {
+			b.noLine = true
+			return nil, io.EOF
+		}
+		delimiterStartPos := b.lastDelimiter()
+		if delimiterStartPos >= 0 {
+			if line, ok := b.completeLine(b.removeLineFromBuffer(delimiterStartPos)); ok {
+				return line, nil
+			}
 		} else {
 			if b.endOfFile() {
-				return b.removeLineFromBuffer(-1), io.EOF
+				if b.longFirstLine() {
+					if line, ok := b.handleLongLine(); ok {
+						return line, nil
+					}
+					continue
+				}
+				line, ok := b.completeLine(b.removeLineFromBuffer(-1))
+				b.noLine = !ok
+				return line, io.EOF
+			}
+			if b.bufferFull() {
+				if line, ok := b.handleLongLine(); ok {
+					return line, nil
+				}
+				continue
 			}
 			if b.err = b.read(); b.err != nil {
-				return "", b.err
+				return nil, b.err
 			}
 		}
 	}
 }
 
+func (b *backward) LineWithRange() (string, LineInfo, error) {
+	line, err := b.LineBytes()
+	return string(line), b.info, err
+}
+
 func (b *backward) Position() int {
+	return int(b.Position64())
+}
+
+func (b *backward) Position64() int64 {
 	if b.readerLineEndPos <= 0 {
 		return endPosition
 	}
 	return b.readerLineEndPos
 }
+
+func (b *backward) Scan() bool {
+	if b.err != nil || b.endOfScan() && !b.leadingLine {
+		b.line = nil
+		return false
+	}
+	line, err := b.LineBytes()
+	if err == nil && trailingLine(line, b.info) {
+		line, err = b.LineBytes()
+	}
+	if err != nil && err != io.EOF {
+		b.line = nil
+		return false
+	}
+	if err == io.EOF && (b.noLine || trailingLine(line, b.info)) {
+		b.line = nil
+		return false
+	}
+	b.line = line
+	return true
+}
+
+func (b *backward) Text() string {
+	return string(b.line)
+}
+
+func (b *backward) Bytes() []byte {
+	return b.line
+}
+
+func (b *backward) Truncated() bool {
+	return b.info.Truncated
+}
+
+func (b *backward) Continued() bool {
+	return b.info.Continued
+}
+
+func (b *backward) Err() error {
+	return b.err
+}
+
+func (b *backward) Lines() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for b.Scan() {
+			if !yield(b.Text()) {
+				return
+			}
+		}
+	}
+}
+
+func (b *backward) LinesWithPosition() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for b.Scan() {
+			if !yield(int(b.info.Start), b.Text()) {
+				return
+			}
+		}
+	}
+}
+
+func (b *backward) LinesWithPosition64() iter.Seq2[int64, string] {
+	return func(yield func(int64, string) bool) {
+		for b.Scan() {
+			if !yield(b.info.Start, b.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// Close stops prefetching and returns the buffers of a scanner with WithBufferPool. The scanner
+// cannot be used after Close.
+func (b *backward) Close() error {
+	if b.err == nil {
+		b.err = ErrScannerClosed
+	}
+	release(b.pool, b.chunk)
+	release(b.pool, b.storage)
+	b.chunk, b.storage, b.buffer, b.line = nil, nil, nil, nil
+	b.bufferStartPos = 0
+	if b.prefetcher == nil {
+		return nil
+	}
+	return b.prefetcher.Close()
+}
+
+func (b *backward) Stats() Stats {
+	return b.stats
+}
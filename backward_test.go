@@ -1,6 +1,7 @@
 package linescanner
 
 import (
+	"bufio"
 	"errors"
 	"io"
 	"strings"
@@ -30,8 +31,8 @@ func TestBackward_NewBackward(t *testing.T) {
 
 	// then
 	assert.Equal(t, backward.reader, reader)
-	assert.Equal(t, backward.readerPos, position)
-	assert.Equal(t, backward.readerLineEndPos, position)
+	assert.Equal(t, backward.readerPos, int64(position))
+	assert.Equal(t, backward.readerLineEndPos, int64(position))
 	assert.Equal(t, backward.maxChunkSize, defaultMaxChunkSize)
 	assert.Equal(t, backward.maxBufferSize, defaultMaxBufferSize)
 }
@@ -72,8 +73,8 @@ func TestBackward_NewBackwardWithSize(t *testing.T) {
 
 	// then
 	assert.Equal(t, backward.reader, reader)
-	assert.Equal(t, backward.readerPos, position)
-	assert.Equal(t, backward.readerLineEndPos, position)
+	assert.Equal(t, backward.readerPos, int64(position))
+	assert.Equal(t, backward.readerLineEndPos, int64(position))
 	assert.Equal(t, backward.maxChunkSize, maxChunkSize)
 	assert.Equal(t, backward.maxBufferSize, maxBufferSize)
 }
@@ -276,17 +277,18 @@ func TestBackward_AllocateBuffer_BufferOverflow(t *testing.T) {
 	// then
 	assert.Equal(t, err, ErrBufferOverflow)
 	assert.Equal(t, backward.buffer, buffer)
-	assert.Equal(t, backward.readerPos, 0)
+	assert.Equal(t, backward.readerPos, int64(0))
 }
 
 func TestBackward_AllocateBuffer_BufferExpanded(t *testing.T) {
 	// given
 	chunk := []byte("abcd")
-	buffer := make([]byte, 1, len(chunk)+1)
-	buffer[0] = 'e'
-	backward := NewBackwardWithSize(strings.NewReader(""), 0, len(chunk), cap(buffer))
+	storage := []byte("   e")
+	backward := NewBackwardWithSize(strings.NewReader(""), 0, len(chunk), len(chunk)+1)
 	backward.chunk = chunk
-	backward.buffer = buffer
+	backward.storage = storage
+	backward.bufferStartPos = 3
+	backward.buffer = storage[3:]
 
 	// when
 	err := backward.allocateBuffer()
@@ -294,17 +296,18 @@ func TestBackward_AllocateBuffer_BufferExpanded(t *testing.T) {
 	// then
 	assert.Nil(t, err)
 	assert.Equal(t, backward.buffer, []byte("abcde"))
-	assert.Equal(t, cap(backward.buffer), len(backward.buffer))
+	assert.Equal(t, cap(backward.storage), len(backward.buffer))
 }
 
 func TestBackward_AllocateBuffer_BufferReused(t *testing.T) {
 	// given
 	chunk := []byte("abcd")
-	buffer := make([]byte, 1, 10)
-	buffer[0] = 'e'
-	backward := NewBackwardWithSize(strings.NewReader(""), 0, len(chunk), cap(buffer))
+	storage := []byte("         e")
+	backward := NewBackwardWithSize(strings.NewReader(""), 0, len(chunk), len(storage))
 	backward.chunk = chunk
-	backward.buffer = buffer
+	backward.storage = storage
+	backward.bufferStartPos = 9
+	backward.buffer = storage[9:]
 
 	// when
 	err := backward.allocateBuffer()
@@ -312,7 +315,28 @@ func TestBackward_AllocateBuffer_BufferReused(t *testing.T) {
 	// then
 	assert.Nil(t, err)
 	assert.Equal(t, backward.buffer, []byte("abcde"))
-	assert.Equal(t, cap(backward.buffer), 10)
+	assert.Equal(t, backward.bufferStartPos, 5)
+	assert.Same(t, &backward.storage[0], &storage[0])
+}
+
+func TestBackward_AllocateBuffer_BufferShifted(t *testing.T) {
+	// given
+	chunk := []byte("abcd")
+	storage := []byte(" e        ")
+	backward := NewBackwardWithSize(strings.NewReader(""), 0, len(chunk), len(storage))
+	backward.chunk = chunk
+	backward.storage = storage
+	backward.bufferStartPos = 1
+	backward.buffer = storage[1:2]
+
+	// when
+	err := backward.allocateBuffer()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, backward.buffer, []byte("abcde"))
+	assert.Equal(t, backward.bufferStartPos, 5)
+	assert.Same(t, &backward.storage[0], &storage[0])
 }
 
 func TestBackward_RemoveLineFromBuffer(t *testing.T) {
@@ -324,10 +348,10 @@ func TestBackward_RemoveLineFromBuffer(t *testing.T) {
 	line := backward.removeLineFromBuffer(2)
 
 	// then
-	assert.Equal(t, line, "defg")
+	assert.Equal(t, line, []byte("defg"))
 	assert.Equal(t, len(backward.buffer), 2)
 	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerLineEndPos, 16-(len(line) /* line feed */ +1 /* carrage return */ +1))
+	assert.Equal(t, backward.readerLineEndPos, int64(16-(len(line) /* line feed */ +1 /* carrage return */ +1)))
 }
 
 func TestBackward_RemoveLineFromBuffer_NoLineFeed(t *testing.T) {
@@ -339,10 +363,10 @@ func TestBackward_RemoveLineFromBuffer_NoLineFeed(t *testing.T) {
 	line := backward.removeLineFromBuffer(-1)
 
 	// then
-	assert.Equal(t, line, "abcde")
+	assert.Equal(t, line, []byte("abcde"))
 	assert.Equal(t, len(backward.buffer), 0)
 	assert.Equal(t, cap(backward.buffer), 5)
-	assert.Equal(t, backward.readerLineEndPos, 0)
+	assert.Equal(t, backward.readerLineEndPos, int64(0))
 }
 
 func TestBackward_Read(t *testing.T) {
@@ -359,7 +383,7 @@ func TestBackward_Read(t *testing.T) {
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("ijkl"))
 	assert.Equal(t, cap(backward.buffer), 4)
-	assert.Equal(t, backward.readerPos, 10)
+	assert.Equal(t, backward.readerPos, int64(10))
 	assert.False(t, backward.endOfFile())
 
 	// when
@@ -371,7 +395,7 @@ func TestBackward_Read(t *testing.T) {
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("fgh\nijkl"))
 	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerPos, 6)
+	assert.Equal(t, backward.readerPos, int64(6))
 	assert.False(t, backward.endOfFile())
 
 	// when
@@ -383,7 +407,7 @@ func TestBackward_Read(t *testing.T) {
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("cd\nefgh\nijkl"))
 	assert.Equal(t, cap(backward.buffer), 12)
-	assert.Equal(t, backward.readerPos, 2)
+	assert.Equal(t, backward.readerPos, int64(2))
 	assert.False(t, backward.endOfFile())
 
 	// when
@@ -395,7 +419,7 @@ func TestBackward_Read(t *testing.T) {
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("abcd\nefgh\nijkl"))
 	assert.Equal(t, cap(backward.buffer), 14)
-	assert.Equal(t, backward.readerPos, 0)
+	assert.Equal(t, backward.readerPos, int64(0))
 	assert.True(t, backward.endOfFile())
 }
 
@@ -410,8 +434,8 @@ func TestBackward_Read_AllocateChunkError(t *testing.T) {
 	err := backward.read()
 
 	// then
-	assert.Equal(t, err, readErr)
-	assert.Equal(t, backward.readerPos, 10)
+	assert.ErrorIs(t, err, readErr)
+	assert.Equal(t, backward.readerPos, int64(10))
 }
 
 func TestBackward_Read_AllocateBufferError(t *testing.T) {
@@ -426,8 +450,8 @@ func TestBackward_Read_AllocateBufferError(t *testing.T) {
 	err := backward.read()
 
 	// then
-	assert.Equal(t, err, ErrBufferOverflow)
-	assert.Equal(t, backward.readerPos, 0)
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	assert.Equal(t, backward.readerPos, int64(0))
 }
 
 func TestBackward_Line(t *testing.T) {
@@ -444,9 +468,9 @@ func TestBackward_Line(t *testing.T) {
 	assert.Equal(t, backward.chunk, []byte("def\n"))
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("def"))
-	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerPos, 6)
-	assert.Equal(t, backward.readerLineEndPos, 9)
+	assert.Equal(t, cap(backward.storage), 8)
+	assert.Equal(t, backward.readerPos, int64(6))
+	assert.Equal(t, backward.readerLineEndPos, int64(9))
 	assert.False(t, backward.endOfFile())
 	assert.False(t, backward.endOfScan())
 
@@ -459,9 +483,9 @@ func TestBackward_Line(t *testing.T) {
 	assert.Equal(t, backward.chunk, []byte("b\r\nc"))
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("b\r"))
-	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerPos, 2)
-	assert.Equal(t, backward.readerLineEndPos, 4)
+	assert.Equal(t, cap(backward.storage), 8)
+	assert.Equal(t, backward.readerPos, int64(2))
+	assert.Equal(t, backward.readerLineEndPos, int64(4))
 	assert.False(t, backward.endOfFile())
 	assert.False(t, backward.endOfScan())
 
@@ -474,9 +498,9 @@ func TestBackward_Line(t *testing.T) {
 	assert.Equal(t, backward.chunk, []byte("a\n"))
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Equal(t, backward.buffer, []byte("a"))
-	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerPos, 0)
-	assert.Equal(t, backward.readerLineEndPos, 1)
+	assert.Equal(t, cap(backward.storage), 8)
+	assert.Equal(t, backward.readerPos, int64(0))
+	assert.Equal(t, backward.readerLineEndPos, int64(1))
 	assert.True(t, backward.endOfFile())
 	assert.False(t, backward.endOfScan())
 
@@ -489,9 +513,9 @@ func TestBackward_Line(t *testing.T) {
 	assert.Equal(t, backward.chunk, []byte("a\n"))
 	assert.Equal(t, cap(backward.chunk), 4)
 	assert.Empty(t, backward.buffer)
-	assert.Equal(t, cap(backward.buffer), 8)
-	assert.Equal(t, backward.readerPos, 0)
-	assert.Equal(t, backward.readerLineEndPos, 0)
+	assert.Equal(t, cap(backward.storage), 8)
+	assert.Equal(t, backward.readerPos, int64(0))
+	assert.Equal(t, backward.readerLineEndPos, int64(0))
 	assert.True(t, backward.endOfFile())
 	assert.True(t, backward.endOfScan())
 }
@@ -507,14 +531,14 @@ func TestBackward_Line_Error(t *testing.T) {
 	line, err := backward.Line()
 
 	// then
-	assert.Equal(t, err, readErr)
+	assert.ErrorIs(t, err, readErr)
 	assert.Empty(t, line)
 
 	// when
 	line, err = backward.Line()
 
 	// then
-	assert.Equal(t, err, readErr)
+	assert.ErrorIs(t, err, readErr)
 	assert.Empty(t, line)
 }
 
@@ -598,3 +622,825 @@ func TestBackward_Position(t *testing.T) {
 	assert.Equal(t, line, "abcdefgh")
 	assert.Equal(t, backward.Position(), endPosition)
 }
+
+func TestBackward_LineBytes(t *testing.T) {
+	// given
+	data := "ab\r\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data))
+
+	// when
+	line, err := backward.LineBytes()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, []byte("cd"))
+	assert.Equal(t, &line[0], &backward.buffer[:cap(backward.buffer)][4])
+
+	// when
+	line, err = backward.LineBytes()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, []byte("ab"))
+	assert.Equal(t, backward.Position(), endPosition)
+
+	// when
+	line, err = backward.LineBytes()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Nil(t, line)
+}
+
+func TestBackward_LineBytes_Error(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	var scanner ByteLineScanner = NewBackward(reader, 100)
+
+	// when
+	line, err := scanner.LineBytes()
+
+	// then
+	assert.ErrorIs(t, err, readErr)
+	assert.Nil(t, line)
+}
+
+func TestBackward_Scan(t *testing.T) {
+	// given
+	data := "a\nb\r\n\nc"
+	backward := NewBackwardWithSize(strings.NewReader(data), len(data), 2, 4)
+
+	// when
+	var lines []string
+	for backward.Scan() {
+		lines = append(lines, backward.Text())
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"c", "", "b", "a"})
+	assert.Nil(t, backward.Bytes())
+	assert.False(t, backward.Scan())
+}
+
+func TestBackward_Scan_TrailingDelimiter(t *testing.T) {
+	tests := []struct {
+		data      string
+		delimiter []byte
+		lines     []string
+	}{
+		{"a\nb\n", []byte("\n"), []string{"b", "a"}},
+		{"a\r\nb\r\n", []byte("\n"), []string{"b", "a"}},
+		{"a\n\n", []byte("\n"), []string{"", "a"}},
+		{"", []byte("\n"), nil},
+		{"\n", []byte("\n"), []string{""}},
+		{"\na\n", []byte("\n"), []string{"a", ""}},
+		{"--a--", []byte("--"), []string{"a", ""}},
+		{"a\x00b\x00", []byte{0}, []string{"b", "a"}},
+	}
+
+	for _, test := range tests {
+		// given
+		backward := NewBackward(strings.NewReader(test.data), len(test.data), WithDelimiter(test.delimiter), WithChunkSize(2), WithMaxBufferSize(4))
+
+		// when
+		var lines []string
+		for backward.Scan() {
+			lines = append(lines, backward.Text())
+		}
+
+		// then
+		assert.Nil(t, backward.Err())
+		assert.Equal(t, lines, test.lines, test.data)
+	}
+}
+
+func TestBackward_Scan_Bytes(t *testing.T) {
+	// given
+	data := "ab\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data))
+
+	// when
+	ok := backward.Scan()
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, backward.Bytes(), []byte("cd"))
+	assert.Equal(t, backward.Text(), "cd")
+}
+
+func TestBackward_Scan_AlreadyEndOfScan(t *testing.T) {
+	// given
+	backward := NewBackward(strings.NewReader("abc"), endPosition)
+
+	// when
+	ok := backward.Scan()
+
+	// then
+	assert.False(t, ok)
+	assert.Nil(t, backward.Err())
+}
+
+func TestBackward_Scan_Error(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	backward := NewBackward(reader, 100)
+
+	// when
+	ok := backward.Scan()
+
+	// then
+	assert.False(t, ok)
+	assert.ErrorIs(t, backward.Err(), readErr)
+	assert.Empty(t, backward.Text())
+}
+
+func TestBackward_Lines(t *testing.T) {
+	// given
+	data := "a\nbc\r\nd"
+	backward := NewBackwardWithSize(strings.NewReader(data), len(data), 2, 4)
+
+	// when
+	var lines []string
+	for line := range backward.Lines() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"d", "bc", "a"})
+}
+
+func TestBackward_Lines_Break(t *testing.T) {
+	// given
+	data := "a\nb\nc"
+	backward := NewBackward(strings.NewReader(data), len(data))
+
+	// when
+	var lines []string
+	for line := range backward.Lines() {
+		lines = append(lines, line)
+		break
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"c"})
+	assert.Equal(t, backward.Position(), 3)
+
+	// when
+	for line := range backward.Lines() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"c", "b", "a"})
+}
+
+func TestBackward_LinesWithPosition(t *testing.T) {
+	// given
+	data := "a\nbc\r\nd"
+	backward := NewBackward(strings.NewReader(data), len(data))
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range backward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, positions, []int{6, 2, 0})
+	assert.Equal(t, lines, []string{"d", "bc", "a"})
+}
+
+func TestBackward_Lines_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "a\nbc\r\n\nd\n"
+
+	// when
+	var lines []string
+	for line := range NewBackward(strings.NewReader(data), len(data)).Lines() {
+		lines = append(lines, line)
+	}
+	var positions []int
+	for position := range NewBackward(strings.NewReader(data), len(data)).LinesWithPosition() {
+		positions = append(positions, position)
+	}
+	var positions64 []int64
+	for position := range NewBackward(strings.NewReader(data), len(data)).LinesWithPosition64() {
+		positions64 = append(positions64, position)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"d", "", "bc", "a"})
+	assert.Equal(t, positions, []int{7, 6, 2, 0})
+	assert.Equal(t, positions64, []int64{7, 6, 2, 0})
+}
+
+func TestBackward_Lines_LeadingDelimiter(t *testing.T) {
+	// given
+	data := "\na\r\nb"
+
+	// when
+	var lines []string
+	for line := range NewBackward(strings.NewReader(data), len(data)).Lines() {
+		lines = append(lines, line)
+	}
+	var positions64 []int64
+	for position := range NewBackward(strings.NewReader(data), len(data)).LinesWithPosition64() {
+		positions64 = append(positions64, position)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"b", "a", ""})
+	assert.Equal(t, positions64, []int64{4, 1, 0})
+}
+
+func TestBackward_LinesWithPosition_BufferOverflow(t *testing.T) {
+	// given
+	data := "a\nbcdef\ng"
+	backward := NewBackwardWithSize(strings.NewReader(data), len(data), 2, 4)
+
+	// when
+	var lines []string
+	for _, line := range backward.LinesWithPosition() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.ErrorIs(t, backward.Err(), ErrBufferOverflow)
+	assert.Equal(t, lines, []string{"g"})
+}
+
+func TestBackward_NewBackwardWithDelimiter(t *testing.T) {
+	// given
+	delimiter := []byte{0}
+
+	// when
+	backward := NewBackwardWithDelimiter(strings.NewReader(""), 0, delimiter)
+	delimiter[0] = 'x'
+
+	// then
+	assert.Equal(t, backward.delimiter, []byte{0})
+	assert.True(t, backward.keepCarriageReturn)
+	assert.Equal(t, backward.maxChunkSize, defaultMaxChunkSize)
+	assert.Equal(t, backward.maxBufferSize, defaultMaxBufferSize)
+}
+
+func TestBackward_NewBackwardWithDelimiter_ErrEmptyDelimiter(t *testing.T) {
+	assert.PanicsWithValue(t, ErrEmptyDelimiter, func() {
+		NewBackwardWithDelimiter(strings.NewReader(""), 0, []byte{})
+	})
+}
+
+func TestBackward_Line_SingleByteDelimiter(t *testing.T) {
+	// given
+	data := "a\r\x00bc\x00\x00d"
+	backward := NewBackwardWithDelimiter(strings.NewReader(data), len(data), []byte{0})
+
+	// when
+	var lines []string
+	for backward.Scan() {
+		lines = append(lines, backward.Text())
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"d", "", "bc", "a\r"})
+}
+
+func TestBackward_Line_MultiByteDelimiter(t *testing.T) {
+	// given
+	data := "ab--cde--f-g--h"
+	backward := NewBackwardWithDelimiter(strings.NewReader(data), len(data), []byte("--"))
+	backward.maxChunkSize = 3
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range backward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"h", "f-g", "cde", "ab"})
+	assert.Equal(t, positions, []int{14, 9, 4, 0})
+}
+
+func TestBackward_Line_MultiByteDelimiterAcrossChunks(t *testing.T) {
+	// given
+	data := "ab<|>cdefgh<|><<|>i|>j<|"
+
+	for chunkSize := 1; chunkSize <= 6; chunkSize++ {
+		backward := NewBackward(strings.NewReader(data), len(data), WithDelimiter([]byte("<|>")), WithChunkSize(chunkSize))
+
+		// when
+		var lines []string
+		for line := range backward.Lines() {
+			lines = append(lines, line)
+		}
+
+		// then
+		assert.Nil(t, backward.Err())
+		assert.Equal(t, lines, []string{"i|>j<|", "<", "cdefgh", "ab"}, chunkSize)
+	}
+}
+
+func TestBackward_Line_MultiByteDelimiterPosition(t *testing.T) {
+	// given
+	data := "ab\r\n\r\ncd"
+	backward := NewBackwardWithDelimiter(strings.NewReader(data), len(data), []byte("\r\n\r\n"))
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, backward.Position(), 2)
+
+	// given
+	backward = NewBackwardWithDelimiter(strings.NewReader(data), backward.Position(), []byte("\r\n\r\n"))
+
+	// when
+	line, err = backward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ab")
+}
+
+func TestBackward_NewBackward_WithOptions(t *testing.T) {
+	// given
+	data := "ab\ncd\r"
+
+	// when
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(8), WithKeepCarriageReturn())
+
+	// then
+	assert.Equal(t, backward.maxChunkSize, 2)
+	assert.Equal(t, backward.maxBufferSize, 8)
+	assert.True(t, backward.keepCarriageReturn)
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd\r")
+}
+
+func TestBackward_NewBackward_ErrSplitFuncUnsupported(t *testing.T) {
+	assert.PanicsWithValue(t, ErrSplitFuncUnsupported, func() {
+		NewBackward(strings.NewReader(""), 0, WithSplitFunc(bufio.ScanWords))
+	})
+}
+
+func TestBackward_TryNewBackward(t *testing.T) {
+	// given
+	reader := strings.NewReader("abcd")
+
+	// when
+	backward, err := TryNewBackward(reader, 4, WithChunkSize(2))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, backward.reader, reader)
+	assert.Equal(t, backward.readerPos, int64(4))
+	assert.Equal(t, backward.maxChunkSize, 2)
+}
+
+func TestBackward_TryNewBackward_Error(t *testing.T) {
+	// given
+	tests := []struct {
+		reader   io.ReaderAt
+		position int
+		opts     []Option
+		err      error
+	}{
+		{nil, 0, nil, ErrNilReader},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(0)}, ErrInvalidMaxChunkSize},
+		{strings.NewReader(""), 0, []Option{WithMaxBufferSize(0)}, ErrInvalidMaxBufferSize},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(10), WithMaxBufferSize(5)}, ErrGreaterBufferSize},
+		{strings.NewReader(""), 0, []Option{WithAdaptiveChunkSize(0)}, ErrInvalidMinChunkSize},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(8), WithAdaptiveChunkSize(16)}, ErrInvalidMinChunkSize},
+		{strings.NewReader(""), 0, []Option{WithSplitFunc(bufio.ScanLines)}, ErrSplitFuncUnsupported},
+		{strings.NewReader("abcd"), 5, nil, ErrInvalidPosition},
+		{strings.NewReader("abcd"), -2, nil, ErrInvalidPosition},
+	}
+
+	for _, test := range tests {
+		// when
+		backward, err := TryNewBackward(test.reader, test.position, test.opts...)
+
+		// then
+		assert.Equal(t, err, test.err)
+		assert.Nil(t, backward)
+	}
+}
+
+func TestBackward_Line_ScanError(t *testing.T) {
+	// given
+	data := "ab\ncdefgh\nij"
+	backward := NewBackwardWithSize(strings.NewReader(data), len(data), 2, 4)
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ij")
+
+	// when
+	_, err = backward.Line()
+
+	// then
+	var scanErr *ScanError
+	assert.True(t, errors.As(err, &scanErr))
+	assert.Equal(t, scanErr.Op, opBuffer)
+	assert.Equal(t, scanErr.Direction, Backward)
+	assert.Equal(t, scanErr.Offset, int64(9))
+	assert.Equal(t, scanErr.ChunkOffset, int64(4))
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestBackward_Line_ScanErrorInvalidPosition(t *testing.T) {
+	// given
+	backward := NewBackwardWithSize(strings.NewReader("abcd"), 10, 4, 4)
+
+	// when
+	_, err := backward.Line()
+
+	// then
+	assert.Equal(t, err, &ScanError{Op: opRead, Direction: Backward, Offset: 10, ChunkOffset: 6, Err: ErrInvalidPosition})
+}
+
+func TestBackward_Line_LongLineTruncate(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.False(t, backward.Truncated())
+
+	// when
+	line, err = backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "abc")
+	assert.True(t, backward.Truncated())
+	assert.False(t, backward.Continued())
+	assert.Equal(t, backward.info.Start, int64(3))
+	assert.Equal(t, backward.Position(), 2)
+
+	// when
+	line, err = backward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ab")
+	assert.False(t, backward.Truncated())
+}
+
+func TestBackward_Line_LongLineTruncateAtStartOfFile(t *testing.T) {
+	// given
+	data := "abcdefghij\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+
+	// when
+	var lines []string
+	for backward.Scan() {
+		lines = append(lines, backward.Text())
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"cd", "abc"})
+	assert.True(t, backward.Truncated())
+}
+
+func TestBackward_Line_LongLineTruncateLikeForward(t *testing.T) {
+	tests := []struct {
+		data      string
+		opts      []Option
+		lines     []string
+		truncated []bool
+	}{
+		{"0123456789\n", nil, []string{"0123456"}, []bool{true}},
+		{"0123456789\r\n", nil, []string{"0123456"}, []bool{true}},
+		{"0123456789\n", []Option{WithKeepCarriageReturn()}, []string{"01234567"}, []bool{true}},
+		{"0123456789--", []Option{WithDelimiter([]byte("--"))}, []string{"0123456"}, []bool{true}},
+		{"01234567\nab\n", nil, []string{"0123456", "ab"}, []bool{true, false}},
+		{"0123456\nab\n", nil, []string{"0123456", "ab"}, []bool{false, false}},
+		{"ab\n01234567", nil, []string{"ab", "0123456"}, []bool{false, true}},
+		{"ab\n0123456", nil, []string{"ab", "0123456"}, []bool{false, false}},
+		{"01234567", nil, []string{"0123456"}, []bool{true}},
+		{"0123456--ab", []Option{WithDelimiter([]byte("--"))}, []string{"0123456", "ab"}, []bool{true, false}},
+		{"ab--012345", []Option{WithDelimiter([]byte("--"))}, []string{"ab", "012345"}, []bool{false, false}},
+		{"bab\nxx\n", []Option{WithChunkSize(1), WithMaxBufferSize(3)}, []string{"ba", "xx"}, []bool{true, false}},
+	}
+
+	for _, test := range tests {
+		// given
+		opts := append([]Option{WithChunkSize(3), WithMaxBufferSize(8), WithLongLinePolicy(LongLineTruncate)}, test.opts...)
+		forward := NewForward(strings.NewReader(test.data), 0, opts...)
+		backward := NewBackward(strings.NewReader(test.data), len(test.data), opts...)
+
+		// when
+		var forwardLines, backwardLines []string
+		var forwardTruncated, backwardTruncated []bool
+		for forward.Scan() {
+			forwardLines = append(forwardLines, forward.Text())
+			forwardTruncated = append(forwardTruncated, forward.Truncated())
+		}
+		for backward.Scan() {
+			backwardLines = append([]string{backward.Text()}, backwardLines...)
+			backwardTruncated = append([]bool{backward.Truncated()}, backwardTruncated...)
+		}
+
+		// then
+		assert.Nil(t, forward.Err())
+		assert.Nil(t, backward.Err())
+		assert.Equal(t, forwardLines, test.lines, test.data)
+		assert.Equal(t, forwardTruncated, test.truncated, test.data)
+		assert.Equal(t, backwardLines, test.lines, test.data)
+		assert.Equal(t, backwardTruncated, test.truncated, test.data)
+	}
+}
+
+func TestBackward_Line_LongLineSkip(t *testing.T) {
+	// given
+	data := "abcdefghij\nab\nabcdefghij\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineSkip))
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range backward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"cd", "ab"})
+	assert.Equal(t, positions, []int{25, 11})
+	assert.Equal(t, backward.Position(), endPosition)
+}
+
+func TestBackward_Line_LongLineFragment(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd"
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+
+	// when
+	var lines []string
+	var continued []bool
+	for backward.Scan() {
+		lines = append(lines, backward.Text())
+		continued = append(continued, backward.Continued())
+	}
+
+	// then
+	assert.Nil(t, backward.Err())
+	assert.Equal(t, lines, []string{"cd", "ij", "fgh", "cde", "ab", "ab"})
+	assert.Equal(t, continued, []bool{false, true, true, true, false, false})
+}
+
+func TestBackward_AllocateChunk_LongLineFitChunk(t *testing.T) {
+	// given
+	data := "abcdefg"
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(3), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+	backward.buffer = []byte("efg")
+	backward.readerPos = 4
+
+	// when
+	err := backward.read()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, backward.buffer, []byte("defg"))
+	assert.Equal(t, backward.readerPos, int64(3))
+}
+
+func TestBackward_LineWithRange(t *testing.T) {
+	// given
+	data := "ab\r\ncd\nef"
+	backward := NewBackwardWithSize(strings.NewReader(data), len(data), 2, 4)
+
+	// when
+	line, info, err := backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ef")
+	assert.Equal(t, info, LineInfo{Start: 7, End: 9})
+
+	// when
+	line, info, err = backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 4, End: 6, Terminated: true})
+
+	// when
+	line, info, err = backward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, CarriageReturn: true, Terminated: true})
+
+	// when
+	line, info, err = backward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Empty(t, line)
+	assert.Equal(t, info, LineInfo{})
+}
+
+func TestBackward_LineWithRange_FromPosition(t *testing.T) {
+	// given
+	data := "ab\ncd\r\nef"
+	backward := NewBackward(strings.NewReader(data), 6)
+
+	// when
+	line, info, err := backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 3, End: 5, CarriageReturn: true, Terminated: true})
+}
+
+func TestBackward_LineWithRange_LongLine(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd"
+
+	// when
+	backward := NewBackward(strings.NewReader(data), 14, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+	line, info, err := backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "abc")
+	assert.Equal(t, info, LineInfo{Start: 3, End: 13, CarriageReturn: true, Terminated: true, Truncated: true})
+
+	// when
+	backward = NewBackward(strings.NewReader(data), 14, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+	var infos []LineInfo
+	for {
+		_, info, err := backward.LineWithRange()
+		infos = append(infos, info)
+		if err != nil {
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, infos, []LineInfo{
+		{Start: 11, End: 13, CarriageReturn: true, Terminated: true, Continued: true},
+		{Start: 8, End: 11, Continued: true},
+		{Start: 5, End: 8, Continued: true},
+		{Start: 3, End: 5},
+		{Start: 0, End: 2, Terminated: true},
+	})
+}
+
+func TestBackward_LargeOffset(t *testing.T) {
+	// given
+	reader := newSparseReader(sparseOffset, "\nab\ncd")
+	backward, err := TryNewBackward64(reader, reader.Size())
+	assert.Nil(t, err)
+
+	// when
+	line, info, err := backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: sparseOffset + 4, End: sparseOffset + 6})
+	assert.Equal(t, backward.Position64(), sparseOffset+3)
+
+	// when
+	line, info, err = backward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: sparseOffset + 1, End: sparseOffset + 3, Terminated: true})
+	assert.Equal(t, backward.Position64(), sparseOffset)
+}
+
+func TestBackward_LargeOffset_InvalidPosition(t *testing.T) {
+	// given
+	reader := newSparseReader(sparseOffset, "ab")
+
+	// when
+	_, err := TryNewBackward64(reader, reader.Size()+1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+}
+
+func benchmarkBackwardLongLine(b *testing.B, size int) {
+	data := strings.Repeat("a", size) + "\n"
+	reader := strings.NewReader(data)
+	b.SetBytes(int64(size))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		backward := NewBackward(reader, len(data), WithMaxBufferSize(size+1))
+		if _, err := backward.Line(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := backward.Line(); err != io.EOF {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The throughput of these benchmarks stays constant as the line grows, because every byte of a long
+// line is copied a constant number of times.
+func BenchmarkBackward_LongLine64KiB(b *testing.B) {
+	benchmarkBackwardLongLine(b, 64<<10)
+}
+
+func BenchmarkBackward_LongLine256KiB(b *testing.B) {
+	benchmarkBackwardLongLine(b, 256<<10)
+}
+
+func BenchmarkBackward_LongLine1MiB(b *testing.B) {
+	benchmarkBackwardLongLine(b, 1<<20)
+}
+
+func BenchmarkBackward_ShortLines(b *testing.B) {
+	data := strings.Repeat("short line of a log file\n", 40000)
+	reader := strings.NewReader(data)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		backward := NewBackward(reader, len(data))
+		for backward.Scan() {
+		}
+		if err := backward.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBackward_AdaptiveChunkSize(t *testing.T) {
+	// given
+	data := strings.Repeat("a long line of a log file\n", 1000) + "last\n"
+	backward := NewBackward(strings.NewReader(data), len(data), WithAdaptiveChunkSize(8))
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "")
+	line, err = backward.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "last")
+	assert.Equal(t, backward.Stats(), Stats{Reads: 1, BytesRead: 8, ChunkSize: 8, MaxChunkSize: 8})
+
+	// when
+	line, err = backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "a long line of a log file")
+	assert.Equal(t, backward.Stats(), Stats{Reads: 3, BytesRead: 8 + 16 + 32, ChunkSize: 32, MaxChunkSize: 32})
+}
+
+func TestBackward_AdaptiveChunkSize_LongLine(t *testing.T) {
+	// given
+	data := "first\n" + strings.Repeat("x", 1000)
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(256), WithAdaptiveChunkSize(4))
+
+	// when
+	line, err := backward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, strings.Repeat("x", 1000))
+	stats := backward.Stats()
+	assert.Equal(t, stats.MaxChunkSize, 256)
+	assert.Less(t, stats.Reads, 10)
+}
@@ -0,0 +1,284 @@
+package linescanner
+
+import (
+	"bytes"
+	"io"
+)
+
+// Cursor moves over the lines of a reader in both directions, sharing one buffered window.
+// Its position is always the start of a line, or the end of the reader. The prefetch, adaptive
+// chunk size and buffer pool options do not apply to it and are ignored.
+type Cursor struct {
+	reader io.ReaderAt
+
+	maxChunkSize int
+	chunk        []byte
+
+	maxBufferSize int
+	window        []byte
+	windowPos     int64
+	endOfFile     bool
+
+	delimiter          []byte
+	keepCarriageReturn bool
+
+	position int64
+
+	info LineInfo
+	err  error
+}
+
+func NewCursor(reader io.ReaderAt, position int, opts ...Option) *Cursor {
+	return NewCursor64(reader, int64(position), opts...)
+}
+
+func NewCursor64(reader io.ReaderAt, position int64, opts ...Option) *Cursor {
+	c, err := newCursor(reader, position, opts)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TryNewCursor(reader io.ReaderAt, position int, opts ...Option) (*Cursor, error) {
+	return TryNewCursor64(reader, int64(position), opts...)
+}
+
+func TryNewCursor64(reader io.ReaderAt, position int64, opts ...Option) (*Cursor, error) {
+	c, err := newCursor(reader, position, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePosition(reader, position); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func newCursor(reader io.ReaderAt, position int64, opts []Option) (*Cursor, error) {
+	if reader == nil {
+		return nil, ErrNilReader
+	}
+	if position < 0 {
+		return nil, ErrInvalidPosition
+	}
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if o.split != nil {
+		return nil, ErrSplitFuncUnsupported
+	}
+	if o.longLinePolicy != LongLineError {
+		return nil, ErrInvalidLongLinePolicy
+	}
+	return &Cursor{
+		reader:             reader,
+		maxChunkSize:       o.maxChunkSize,
+		maxBufferSize:      o.maxBufferSize,
+		windowPos:          position,
+		delimiter:          o.delimiter,
+		keepCarriageReturn: !o.trimCarriageReturn(),
+		position:           position,
+	}, nil
+}
+
+func (c *Cursor) windowEndPos() int64 {
+	return c.windowPos + int64(len(c.window))
+}
+
+func (c *Cursor) windowOffset() int {
+	return int(c.position - c.windowPos)
+}
+
+func (c *Cursor) newScanError(op string, direction Direction, chunkOffset int64, err error) *ScanError {
+	return &ScanError{
+		Op:          op,
+		Direction:   direction,
+		Offset:      c.position,
+		ChunkOffset: chunkOffset,
+		Err:         err,
+	}
+}
+
+func (c *Cursor) allocateChunk(size int) {
+	if cap(c.chunk) < size {
+		c.chunk = make([]byte, size)
+	}
+	c.chunk = c.chunk[:size]
+}
+
+func (c *Cursor) extendRight() error {
+	chunkOffset := c.windowEndPos()
+	c.allocateChunk(c.maxChunkSize)
+	n, err := c.reader.ReadAt(c.chunk, chunkOffset)
+	if err != nil {
+		if err != io.EOF {
+			return c.newScanError(opRead, Forward, chunkOffset, err)
+		}
+		c.endOfFile = true
+	}
+	c.chunk = c.chunk[:n]
+	windowSize := len(c.window) + n
+	if windowSize > c.maxBufferSize {
+		discardSize := c.windowOffset()
+		copy(c.window, c.window[discardSize:])
+		c.window = c.window[:len(c.window)-discardSize]
+		c.windowPos += int64(discardSize)
+		windowSize -= discardSize
+	}
+	if windowSize > c.maxBufferSize {
+		return c.newScanError(opBuffer, Forward, chunkOffset, ErrBufferOverflow)
+	}
+	c.window = append(c.window, c.chunk...)
+	return nil
+}
+
+func (c *Cursor) extendLeft() error {
+	chunkSize := int(minInt64(c.windowPos, int64(c.maxChunkSize)))
+	chunkOffset := c.windowPos - int64(chunkSize)
+	c.allocateChunk(chunkSize)
+	n, err := c.reader.ReadAt(c.chunk, chunkOffset)
+	if err != nil {
+		if err == io.EOF {
+			err = ErrInvalidPosition
+		}
+		return c.newScanError(opRead, Backward, chunkOffset, err)
+	}
+	if n != chunkSize {
+		return c.newScanError(opRead, Backward, chunkOffset, ErrReadFailure)
+	}
+	windowSize := len(c.window) + chunkSize
+	if windowSize > c.maxBufferSize {
+		c.window = c.window[:c.windowOffset()]
+		c.endOfFile = false
+		windowSize = len(c.window) + chunkSize
+	}
+	if windowSize > c.maxBufferSize {
+		return c.newScanError(opBuffer, Backward, chunkOffset, ErrBufferOverflow)
+	}
+	if windowSize > cap(c.window) {
+		expandedWindow := make([]byte, 0, windowSize)
+		expandedWindow = append(expandedWindow, c.chunk...)
+		expandedWindow = append(expandedWindow, c.window...)
+		c.window = expandedWindow
+	} else {
+		prevWindowSize := len(c.window)
+		c.window = c.window[:windowSize]
+		copy(c.window[chunkSize:], c.window[:prevWindowSize])
+		copy(c.window, c.chunk)
+	}
+	c.windowPos = chunkOffset
+	return nil
+}
+
+func (c *Cursor) newLine(windowStartPos int, windowEndPos int, terminated bool) []byte {
+	line := c.window[windowStartPos:windowEndPos]
+	c.info = LineInfo{
+		Start:      c.windowPos + int64(windowStartPos),
+		End:        c.windowPos + int64(windowEndPos),
+		Terminated: terminated,
+	}
+	if !c.keepCarriageReturn {
+		if trimmed := trimCarriageReturn(line); len(trimmed) < len(line) {
+			line = trimmed
+			c.info.End--
+			c.info.CarriageReturn = true
+		}
+	}
+	return line
+}
+
+func (c *Cursor) Next() (string, error) {
+	line, err := c.NextBytes()
+	return string(line), err
+}
+
+func (c *Cursor) NextWithRange() (string, LineInfo, error) {
+	line, err := c.NextBytes()
+	return string(line), c.info, err
+}
+
+func (c *Cursor) NextBytes() ([]byte, error) {
+	c.info = LineInfo{}
+	if c.err != nil {
+		return nil, c.err
+	}
+	for {
+		lineStartPos := c.windowOffset()
+		lineSize := bytes.Index(c.window[lineStartPos:], c.delimiter)
+		if lineSize >= 0 {
+			nextPos := c.position + int64(lineSize+len(c.delimiter))
+			if nextPos < c.windowEndPos() || c.endOfFile {
+				line := c.newLine(lineStartPos, lineStartPos+lineSize, true)
+				c.position = nextPos
+				if c.endOfFile && nextPos == c.windowEndPos() {
+					return line, io.EOF
+				}
+				return line, nil
+			}
+		} else if c.endOfFile {
+			line := c.newLine(lineStartPos, len(c.window), false)
+			c.position = c.windowEndPos()
+			return line, io.EOF
+		}
+		if c.err = c.extendRight(); c.err != nil {
+			return nil, c.err
+		}
+	}
+}
+
+func (c *Cursor) Prev() (string, error) {
+	line, err := c.PrevBytes()
+	return string(line), err
+}
+
+func (c *Cursor) PrevWithRange() (string, LineInfo, error) {
+	line, err := c.PrevBytes()
+	return string(line), c.info, err
+}
+
+func (c *Cursor) PrevBytes() ([]byte, error) {
+	c.info = LineInfo{}
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.position <= 0 {
+		return nil, io.EOF
+	}
+	for c.windowOffset() < len(c.delimiter) && c.windowPos > 0 {
+		if c.err = c.extendLeft(); c.err != nil {
+			return nil, c.err
+		}
+	}
+	lineEndPos := c.position
+	if bytes.HasSuffix(c.window[:c.windowOffset()], c.delimiter) {
+		lineEndPos -= int64(len(c.delimiter))
+	}
+	for {
+		windowLineEndPos := int(lineEndPos - c.windowPos)
+		delimiterStartPos := bytes.LastIndex(c.window[:windowLineEndPos], c.delimiter)
+		if delimiterStartPos >= 0 {
+			lineStartPos := delimiterStartPos + len(c.delimiter)
+			line := c.newLine(lineStartPos, windowLineEndPos, lineEndPos != c.position)
+			c.position = c.windowPos + int64(lineStartPos)
+			return line, nil
+		}
+		if c.windowPos == 0 {
+			line := c.newLine(0, windowLineEndPos, lineEndPos != c.position)
+			c.position = 0
+			return line, io.EOF
+		}
+		if c.err = c.extendLeft(); c.err != nil {
+			return nil, c.err
+		}
+	}
+}
+
+func (c *Cursor) Position() int {
+	return int(c.position)
+}
+
+func (c *Cursor) Position64() int64 {
+	return c.position
+}
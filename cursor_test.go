@@ -0,0 +1,490 @@
+package linescanner
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type countingReader struct {
+	io.ReaderAt
+	reads int
+}
+
+func (r *countingReader) ReadAt(p []byte, off int64) (int, error) {
+	r.reads++
+	return r.ReaderAt.ReadAt(p, off)
+}
+
+func TestCursor_NewCursor(t *testing.T) {
+	// given
+	reader := strings.NewReader("")
+	position := 100
+
+	// when
+	cursor := NewCursor(reader, position)
+
+	// then
+	assert.Equal(t, cursor.reader, reader)
+	assert.Equal(t, cursor.position, int64(position))
+	assert.Equal(t, cursor.windowPos, int64(position))
+	assert.Empty(t, cursor.window)
+	assert.Equal(t, cursor.maxChunkSize, defaultMaxChunkSize)
+	assert.Equal(t, cursor.maxBufferSize, defaultMaxBufferSize)
+	assert.Equal(t, cursor.delimiter, defaultDelimiter)
+	assert.False(t, cursor.keepCarriageReturn)
+}
+
+func TestCursor_NewCursor_Error(t *testing.T) {
+	// given
+	tests := []struct {
+		reader   io.ReaderAt
+		position int
+		opts     []Option
+		err      error
+	}{
+		{nil, 0, nil, ErrNilReader},
+		{strings.NewReader(""), endPosition, nil, ErrInvalidPosition},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(0)}, ErrInvalidMaxChunkSize},
+		{strings.NewReader(""), 0, []Option{WithSplitFunc(bufio.ScanLines)}, ErrSplitFuncUnsupported},
+		{strings.NewReader(""), 0, []Option{WithLongLinePolicy(LongLineSkip)}, ErrInvalidLongLinePolicy},
+	}
+
+	for _, test := range tests {
+		// then
+		assert.PanicsWithValue(t, test.err, func() {
+			NewCursor(test.reader, test.position, test.opts...)
+		})
+	}
+}
+
+func TestCursor_TryNewCursor(t *testing.T) {
+	// when
+	cursor, err := TryNewCursor(strings.NewReader("abcd"), 4)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, cursor.Position(), 4)
+
+	// when
+	cursor, err = TryNewCursor(strings.NewReader("abcd"), 5)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+	assert.Nil(t, cursor)
+}
+
+func TestCursor_Next(t *testing.T) {
+	// given
+	data := "a\nbc\r\n\ndef"
+	cursor := NewCursor(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(8))
+
+	// when
+	line, err := cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "a")
+	assert.Equal(t, cursor.Position(), 2)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "bc")
+	assert.Equal(t, cursor.Position(), 6)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "")
+	assert.Equal(t, cursor.Position(), 7)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "def")
+	assert.Equal(t, cursor.Position(), 10)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "")
+	assert.Equal(t, cursor.Position(), 10)
+}
+
+func TestCursor_Next_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "a\nb\n"
+	cursor := NewCursor(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	line, err := cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "a")
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "b")
+	assert.Equal(t, cursor.Position(), 4)
+}
+
+func TestCursor_Prev(t *testing.T) {
+	// given
+	data := "a\nbc\r\n\ndef"
+	cursor := NewCursor(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(8))
+
+	// when
+	line, err := cursor.Prev()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "def")
+	assert.Equal(t, cursor.Position(), 7)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "")
+	assert.Equal(t, cursor.Position(), 6)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "bc")
+	assert.Equal(t, cursor.Position(), 2)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "a")
+	assert.Equal(t, cursor.Position(), 0)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "")
+	assert.Equal(t, cursor.Position(), 0)
+}
+
+func TestCursor_Prev_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "a\nb\n"
+	cursor := NewCursor(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	line, err := cursor.Prev()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "b")
+	assert.Equal(t, cursor.Position(), 2)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "a")
+	assert.Equal(t, cursor.Position(), 0)
+}
+
+func TestCursor_NextPrev(t *testing.T) {
+	// given
+	data := "ab\ncd\nef\ngh"
+	reader := &countingReader{ReaderAt: strings.NewReader(data)}
+	cursor := NewCursor(reader, 3, WithChunkSize(4), WithMaxBufferSize(16))
+
+	// when
+	line, err := cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, cursor.Position(), 6)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, cursor.Position(), 3)
+
+	// when
+	line, err = cursor.Prev()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, cursor.Position(), 0)
+
+	// when
+	reads := reader.reads
+	line, err = cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, cursor.Position(), 3)
+	assert.Equal(t, reader.reads, reads)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, reader.reads, reads)
+}
+
+func TestCursor_MultiByteDelimiter(t *testing.T) {
+	// given
+	data := "ab--cd----ef"
+	cursor := NewCursor(strings.NewReader(data), 0, WithChunkSize(3), WithMaxBufferSize(12), WithDelimiter([]byte("--")))
+
+	// when
+	var lines []string
+	for {
+		line, err := cursor.Next()
+		lines = append(lines, line)
+		if err != nil {
+			assert.Equal(t, err, io.EOF)
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ab", "cd", "", "ef"})
+
+	// when
+	lines = nil
+	for {
+		line, err := cursor.Prev()
+		lines = append(lines, line)
+		if err != nil {
+			assert.Equal(t, err, io.EOF)
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ef", "", "cd", "ab"})
+}
+
+func TestCursor_WindowSlides(t *testing.T) {
+	// given
+	data := "ab\ncd\nef\ngh\nij"
+	cursor := NewCursor(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(6))
+
+	// when
+	var lines []string
+	for {
+		line, err := cursor.Next()
+		lines = append(lines, line)
+		if err != nil {
+			assert.Equal(t, err, io.EOF)
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ab", "cd", "ef", "gh", "ij"})
+	assert.LessOrEqual(t, len(cursor.window), 6)
+
+	// when
+	lines = nil
+	for {
+		line, err := cursor.Prev()
+		lines = append(lines, line)
+		if err != nil {
+			assert.Equal(t, err, io.EOF)
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ij", "gh", "ef", "cd", "ab"})
+	assert.LessOrEqual(t, len(cursor.window), 6)
+}
+
+func TestCursor_Next_BufferOverflow(t *testing.T) {
+	// given
+	data := "abcdef\ng"
+	cursor := NewCursor(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	_, err := cursor.Next()
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	var scanErr *ScanError
+	assert.True(t, errors.As(err, &scanErr))
+	assert.Equal(t, scanErr.Direction, Forward)
+	assert.Equal(t, scanErr.Offset, int64(0))
+	assert.Equal(t, scanErr.ChunkOffset, int64(4))
+
+	// when
+	_, err = cursor.Prev()
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestCursor_Prev_BufferOverflow(t *testing.T) {
+	// given
+	data := "a\nbcdefg"
+	cursor := NewCursor(strings.NewReader(data), len(data), WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	_, err := cursor.Prev()
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	var scanErr *ScanError
+	assert.True(t, errors.As(err, &scanErr))
+	assert.Equal(t, scanErr.Direction, Backward)
+	assert.Equal(t, scanErr.Offset, int64(len(data)))
+	assert.Equal(t, scanErr.ChunkOffset, int64(2))
+}
+
+func TestCursor_ReadError(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	cursor := NewCursor(reader, 10)
+
+	// when
+	line, err := cursor.PrevBytes()
+
+	// then
+	assert.ErrorIs(t, err, readErr)
+	assert.Nil(t, line)
+
+	// when
+	line, err = cursor.NextBytes()
+
+	// then
+	assert.ErrorIs(t, err, readErr)
+	assert.Nil(t, line)
+}
+
+func TestCursor_Prev_InvalidPosition(t *testing.T) {
+	// given
+	cursor := NewCursor(strings.NewReader("abcd"), 10, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	_, err := cursor.Prev()
+
+	// then
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+}
+
+func TestCursor_NextWithRange(t *testing.T) {
+	// given
+	data := "ab\r\ncd"
+	cursor := NewCursor(strings.NewReader(data), 0)
+
+	// when
+	line, info, err := cursor.NextWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, CarriageReturn: true, Terminated: true})
+
+	// when
+	line, info, err = cursor.NextWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 4, End: 6})
+}
+
+func TestCursor_PrevWithRange(t *testing.T) {
+	// given
+	data := "ab\r\ncd"
+	cursor := NewCursor(strings.NewReader(data), len(data))
+
+	// when
+	line, info, err := cursor.PrevWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 4, End: 6})
+
+	// when
+	line, info, err = cursor.PrevWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, CarriageReturn: true, Terminated: true})
+
+	// when
+	_, info, err = cursor.PrevWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, info, LineInfo{})
+}
+
+func TestCursor_LargeOffset(t *testing.T) {
+	// given
+	reader := newSparseReader(sparseOffset, "\nab\ncd")
+	cursor, err := TryNewCursor64(reader, sparseOffset+4)
+	assert.Nil(t, err)
+
+	// when
+	line, info, err := cursor.PrevWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: sparseOffset + 1, End: sparseOffset + 3, Terminated: true})
+	assert.Equal(t, cursor.Position64(), sparseOffset+1)
+
+	// when
+	line, err = cursor.Next()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+
+	// when
+	line, info, err = cursor.NextWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: sparseOffset + 4, End: sparseOffset + 6})
+	assert.Equal(t, cursor.Position64(), reader.Size())
+}
@@ -0,0 +1,31 @@
+package linescanner
+
+import (
+	"fmt"
+)
+
+const (
+	opRead   = "read"
+	opBuffer = "buffer"
+	opSplit  = "split"
+	opSeek   = "seek"
+)
+
+// ScanError describes a failed scan. Offset is the start of the line being assembled
+// for forward scans and its end for backward scans; ChunkOffset is where the failing read began.
+type ScanError struct {
+	Op          string
+	Direction   Direction
+	Offset      int64
+	ChunkOffset int64
+	Err         error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("linescanner: %s %s at offset %d (chunk offset %d): %v",
+		e.Direction, e.Op, e.Offset, e.ChunkOffset, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
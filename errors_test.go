@@ -0,0 +1,45 @@
+package linescanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanError_Error(t *testing.T) {
+	// given
+	err := &ScanError{
+		Op:          opBuffer,
+		Direction:   Backward,
+		Offset:      100,
+		ChunkOffset: 60,
+		Err:         ErrBufferOverflow,
+	}
+
+	// when
+	message := err.Error()
+
+	// then
+	assert.Equal(t, message, "linescanner: backward buffer at offset 100 (chunk offset 60): buffer is overflow")
+}
+
+func TestScanError_Unwrap(t *testing.T) {
+	// given
+	var err error = &ScanError{Op: opRead, Direction: Forward, Err: ErrReadFailure}
+
+	// when
+	var scanErr *ScanError
+	ok := errors.As(err, &scanErr)
+
+	// then
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, ErrReadFailure)
+	assert.False(t, errors.Is(err, ErrBufferOverflow))
+}
+
+func TestDirection_String(t *testing.T) {
+	assert.Equal(t, Forward.String(), "forward")
+	assert.Equal(t, Backward.String(), "backward")
+	assert.Equal(t, Direction(-1).String(), "unknown")
+}
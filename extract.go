@@ -0,0 +1,117 @@
+package linescanner
+
+import (
+	"io"
+)
+
+// Head returns the first n lines of reader.
+func Head(reader io.ReaderAt, n int, opts ...Option) ([]string, error) {
+	if n < 0 {
+		return nil, ErrInvalidLineCount
+	}
+	return Range(reader, 0, n, opts...)
+}
+
+// Range returns the lines numbered [from, to), counting from 0.
+func Range(reader io.ReaderAt, from int, to int, opts ...Option) ([]string, error) {
+	if from < 0 || to < from {
+		return nil, ErrInvalidLineRange
+	}
+	forward, err := newForward(reader, 0, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer forward.Close()
+	var lines []string
+	err = forEachLine(forward, func(number int, _ LineInfo, line []byte) bool {
+		if number >= to {
+			return false
+		}
+		if number >= from {
+			lines = append(lines, string(line))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Between returns the lines overlapping the byte range [start, end). start is moved back
+// to the start of its line and the line containing end-1 is returned whole.
+func Between(reader io.ReaderAt, start int64, end int64, opts ...Option) ([]string, error) {
+	if start < 0 || end < start {
+		return nil, ErrInvalidPosition
+	}
+	if start == end {
+		return nil, nil
+	}
+	start, err := lineStart(reader, start, opts)
+	if err != nil {
+		return nil, err
+	}
+	forward, err := newForward(reader, start, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer forward.Close()
+	var lines []string
+	err = forEachLine(forward, func(_ int, info LineInfo, line []byte) bool {
+		if info.Start >= end {
+			return false
+		}
+		lines = append(lines, string(line))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// lineStart returns the start of the line containing position.
+func lineStart(reader io.ReaderAt, position int64, opts []Option) (int64, error) {
+	if position == 0 {
+		return 0, nil
+	}
+	backward, err := newBackward(reader, position, probeOptions(opts))
+	if err != nil {
+		return 0, err
+	}
+	defer backward.Close()
+	if _, err := backward.LineBytes(); err != nil && err != io.EOF {
+		return 0, err
+	}
+	return backward.info.Start, nil
+}
+
+// forEachLine calls fn with every line of forward until fn returns false. Fragments of a long line
+// share its number and the Start of its info. An empty last line after the final delimiter is not a line.
+func forEachLine(forward *forward, fn func(number int, info LineInfo, line []byte) bool) error {
+	number, start, continued := 0, int64(0), false
+	for {
+		line, err := forward.LineBytes()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		info := forward.info
+		if err == io.EOF && (forward.noLine || info.Start == info.End && !info.CarriageReturn) {
+			return nil
+		}
+		if !continued {
+			start = info.Start
+		}
+		info.Start = start
+		if !fn(number, info, line) {
+			return nil
+		}
+		continued = info.Continued
+		if !continued {
+			number++
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
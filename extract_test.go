@@ -0,0 +1,188 @@
+package linescanner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHead(t *testing.T) {
+	// given
+	tests := []struct {
+		data  string
+		n     int
+		lines []string
+	}{
+		{"ab\ncd\nef", 2, []string{"ab", "cd"}},
+		{"ab\r\ncd\n", 5, []string{"ab", "cd"}},
+		{"ab\n\n", 5, []string{"ab", ""}},
+		{"ab\ncd", 0, nil},
+		{"", 1, nil},
+	}
+
+	for _, test := range tests {
+		// when
+		lines, err := Head(strings.NewReader(test.data), test.n, WithChunkSize(2), WithMaxBufferSize(4))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, lines, test.lines, "%q", test.data)
+	}
+}
+
+func TestHead_ErrInvalidLineCount(t *testing.T) {
+	// when
+	_, err := Head(strings.NewReader(""), -1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidLineCount)
+}
+
+func TestRange(t *testing.T) {
+	// given
+	data := "ab\ncd\nef\ngh\n"
+	tests := []struct {
+		from  int
+		to    int
+		lines []string
+	}{
+		{1, 3, []string{"cd", "ef"}},
+		{2, 10, []string{"ef", "gh"}},
+		{4, 5, nil},
+		{1, 1, nil},
+	}
+
+	for _, test := range tests {
+		// when
+		lines, err := Range(strings.NewReader(data), test.from, test.to)
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, lines, test.lines)
+	}
+}
+
+func TestRange_LongLine(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\ncd\n"
+
+	// when
+	lines, err := Range(strings.NewReader(data), 1, 3, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"abc", "def", "ghi", "j", "cd"})
+}
+
+func TestRange_Errors(t *testing.T) {
+	// when
+	_, err := Range(strings.NewReader(""), -1, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidLineRange)
+
+	// when
+	_, err = Range(strings.NewReader(""), 2, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidLineRange)
+
+	// when
+	_, err = Range(nil, 0, 1)
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = Range(strings.NewReader("abcdef"), 0, 1, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestBetween(t *testing.T) {
+	// given
+	data := "ab\ncd\nef\ngh"
+	tests := []struct {
+		start int64
+		end   int64
+		lines []string
+	}{
+		{0, 3, []string{"ab"}},
+		{0, 4, []string{"ab", "cd"}},
+		{4, 7, []string{"cd", "ef"}},
+		{3, 6, []string{"cd"}},
+		{2, 2, nil},
+		{6, 6, nil},
+		{10, 11, []string{"gh"}},
+		{0, 100, []string{"ab", "cd", "ef", "gh"}},
+	}
+
+	for _, test := range tests {
+		// when
+		lines, err := Between(strings.NewReader(data), test.start, test.end, WithChunkSize(2), WithMaxBufferSize(4))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, lines, test.lines, "[%d, %d)", test.start, test.end)
+	}
+}
+
+func TestBetween_LongLine(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\ncd\n"
+
+	// when
+	lines, err := Between(strings.NewReader(data), 8, 9, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"abc"})
+}
+
+func TestBetween_Errors(t *testing.T) {
+	// when
+	_, err := Between(strings.NewReader("ab"), -1, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = Between(strings.NewReader("ab"), 2, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = Between(strings.NewReader("ab"), 3, 4)
+
+	// then
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+}
+
+func TestLineStart(t *testing.T) {
+	// given
+	data := "ab\ncd\n\nef"
+	tests := []struct {
+		position int64
+		start    int64
+	}{
+		{0, 0},
+		{1, 0},
+		{3, 3},
+		{5, 3},
+		{6, 6},
+		{7, 7},
+		{9, 7},
+	}
+
+	for _, test := range tests {
+		// when
+		start, err := lineStart(strings.NewReader(data), test.position, nil)
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, start, test.start, "%d", test.position)
+	}
+}
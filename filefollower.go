@@ -0,0 +1,216 @@
+package linescanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+)
+
+type RotationKind int
+
+const (
+	Truncated RotationKind = iota
+	Rotated
+)
+
+func (k RotationKind) String() string {
+	switch k {
+	case Truncated:
+		return "truncated"
+	case Rotated:
+		return "rotated"
+	}
+	return "unknown"
+}
+
+// RotationEvent reports that a followed file was truncated or replaced.
+// Offset is how far the previous file had been read.
+type RotationEvent struct {
+	Kind   RotationKind
+	Path   string
+	Offset int64
+}
+
+// FileFollower follows the file at a path, starting over from offset 0 when the file is truncated
+// or when the path is rotated to a new file.
+type FileFollower struct {
+	path string
+	file *os.File
+	opts []Option
+
+	follower *Follower
+
+	rotationPolicy RotationPolicy
+	onRotate       func(RotationEvent)
+	draining       bool
+
+	info LineInfo
+	err  error
+}
+
+func FollowFile(path string, position int64, opts ...Option) (*FileFollower, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	follower, err := TryNewFollower64(file, position, opts...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	o := newOptions(opts)
+	return &FileFollower{
+		path:           path,
+		file:           file,
+		opts:           opts,
+		follower:       follower,
+		rotationPolicy: o.rotationPolicy,
+		onRotate:       o.onRotate,
+	}, nil
+}
+
+func (f *FileFollower) detect() (RotationKind, bool, error) {
+	info, err := f.file.Stat()
+	if err != nil {
+		return 0, false, err
+	}
+	if info.Size() < f.follower.forward.readerPos {
+		return Truncated, true, nil
+	}
+	pathInfo, err := os.Stat(f.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if !os.SameFile(info, pathInfo) {
+		return Rotated, true, nil
+	}
+	return 0, false, nil
+}
+
+func (f *FileFollower) restart(kind RotationKind) (bool, error) {
+	event := RotationEvent{
+		Kind:   kind,
+		Path:   f.path,
+		Offset: f.follower.forward.readerPos,
+	}
+	file := f.file
+	if kind == Rotated {
+		var err error
+		if file, err = os.Open(f.path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	forward, err := newForward(file, 0, f.opts)
+	if err != nil {
+		return false, err
+	}
+	f.follower.forward.Close()
+	if file != f.file {
+		f.file.Close()
+		f.file = file
+	}
+	f.follower.forward = forward
+	if f.onRotate != nil {
+		f.onRotate(event)
+	}
+	return true, nil
+}
+
+func (f *FileFollower) Line(ctx context.Context) (string, error) {
+	line, err := f.LineBytes(ctx)
+	return string(line), err
+}
+
+func (f *FileFollower) LineBytes(ctx context.Context) ([]byte, error) {
+	f.info = LineInfo{}
+	for {
+		if f.err = ctx.Err(); f.err != nil {
+			return nil, f.err
+		}
+		line, err := f.follower.forward.LineBytes()
+		if err != io.EOF {
+			f.info = f.follower.forward.info
+			f.err = err
+			return line, err
+		}
+		if f.draining {
+			f.draining = false
+			info := f.follower.forward.info
+			f.follower.forward.resume()
+			// restart closes the old scanner, which hands its buffer back to the pool
+			line = bytes.Clone(line)
+			restarted, err := f.restart(Rotated)
+			if err != nil {
+				f.err = err
+				return nil, err
+			}
+			if restarted && len(line) > 0 {
+				f.info = info
+				return line, nil
+			}
+			continue
+		}
+		f.follower.forward.resume()
+		kind, ok, err := f.detect()
+		if err != nil {
+			f.err = err
+			return nil, err
+		}
+		if ok && kind == Rotated && f.rotationPolicy == RotationDrain {
+			f.draining = true
+			continue
+		}
+		if ok {
+			if _, f.err = f.restart(kind); f.err != nil {
+				return nil, f.err
+			}
+			continue
+		}
+		if f.err = waitPoll(ctx, f.follower.pollInterval); f.err != nil {
+			return nil, f.err
+		}
+	}
+}
+
+func (f *FileFollower) LineWithRange(ctx context.Context) (string, LineInfo, error) {
+	line, err := f.LineBytes(ctx)
+	return string(line), f.info, err
+}
+
+func (f *FileFollower) Position() int {
+	return f.follower.Position()
+}
+
+func (f *FileFollower) Position64() int64 {
+	return f.follower.Position64()
+}
+
+func (f *FileFollower) Err() error {
+	return f.err
+}
+
+func (f *FileFollower) Lines(ctx context.Context) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for {
+			line, err := f.LineBytes(ctx)
+			if err != nil || !yield(string(line)) {
+				return
+			}
+		}
+	}
+}
+
+func (f *FileFollower) Close() error {
+	f.follower.Close()
+	return f.file.Close()
+}
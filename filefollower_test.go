@@ -0,0 +1,201 @@
+package linescanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendFile(t *testing.T, path string, data string) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	assert.Nil(t, err)
+	_, err = file.WriteString(data)
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+}
+
+func followLines(t *testing.T, follower *FileFollower, n int) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var lines []string
+	for len(lines) < n {
+		line, err := follower.Line(ctx)
+		if !assert.Nil(t, err) {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestFileFollower_FollowFile_Errors(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+
+	// when
+	_, err := FollowFile(path, 0)
+
+	// then
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// given
+	appendFile(t, path, "ab\n")
+
+	// when
+	_, err = FollowFile(path, 0, WithRotationPolicy(RotationDrain+1))
+
+	// then
+	assert.Equal(t, err, ErrInvalidRotationPolicy)
+
+	// when
+	_, err = FollowFile(path, 4)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+}
+
+func TestFileFollower_Line_Truncated(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+	appendFile(t, path, "ab\ncd\n")
+	var events []RotationEvent
+	follower, err := FollowFile(path, 0, WithPollInterval(time.Millisecond), WithRotationCallback(func(event RotationEvent) {
+		events = append(events, event)
+	}))
+	assert.Nil(t, err)
+	defer follower.Close()
+	assert.Equal(t, followLines(t, follower, 2), []string{"ab", "cd"})
+
+	// when
+	assert.Nil(t, os.Truncate(path, 0))
+	appendFile(t, path, "ef\n")
+	line, info, err := follower.LineWithRange(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ef")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, Terminated: true})
+	assert.Equal(t, events, []RotationEvent{{Kind: Truncated, Path: path, Offset: 6}})
+	assert.Equal(t, follower.Position64(), int64(3))
+}
+
+func TestFileFollower_Line_RotatedReopen(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+	appendFile(t, path, "ab\npart")
+	var events []RotationEvent
+	follower, err := FollowFile(path, 0, WithPollInterval(time.Millisecond), WithRotationCallback(func(event RotationEvent) {
+		events = append(events, event)
+	}))
+	assert.Nil(t, err)
+	defer follower.Close()
+	assert.Equal(t, followLines(t, follower, 1), []string{"ab"})
+
+	// when
+	assert.Nil(t, os.Rename(path, path+".1"))
+	appendFile(t, path+".1", "ial\n")
+	appendFile(t, path, "new\n")
+	lines := followLines(t, follower, 1)
+
+	// then
+	assert.Equal(t, lines, []string{"new"})
+	assert.Equal(t, events, []RotationEvent{{Kind: Rotated, Path: path, Offset: 7}})
+}
+
+func TestFileFollower_Line_RotatedDrain(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+	appendFile(t, path, "ab\npart")
+	var events []RotationEvent
+	follower, err := FollowFile(path, 0,
+		WithPollInterval(time.Millisecond),
+		WithRotationPolicy(RotationDrain),
+		WithRotationCallback(func(event RotationEvent) {
+			events = append(events, event)
+		}))
+	assert.Nil(t, err)
+	defer follower.Close()
+	assert.Equal(t, followLines(t, follower, 1), []string{"ab"})
+
+	// when
+	assert.Nil(t, os.Rename(path, path+".1"))
+	appendFile(t, path+".1", "ial\nlast")
+	appendFile(t, path, "new\n")
+	lines := followLines(t, follower, 3)
+
+	// then
+	assert.Equal(t, lines, []string{"partial", "last", "new"})
+	assert.Equal(t, events, []RotationEvent{{Kind: Rotated, Path: path, Offset: 15}})
+}
+
+func TestFileFollower_LineBytes_RotatedDrainPooled(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+	appendFile(t, path, "ab\n")
+	pool := newCheckedPool()
+	follower, err := FollowFile(path, 0,
+		WithChunkSize(4),
+		WithPollInterval(time.Millisecond),
+		WithRotationPolicy(RotationDrain),
+		WithBufferPool(pool))
+	assert.Nil(t, err)
+	assert.Equal(t, followLines(t, follower, 1), []string{"ab"})
+	assert.Nil(t, os.Rename(path, path+".1"))
+	appendFile(t, path+".1", "drained")
+	appendFile(t, path, "new\n")
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	line, err := follower.LineBytes(ctx)
+	pool.mu.Lock()
+	for _, buffer := range pool.free {
+		copy(buffer[:cap(buffer)], strings.Repeat("x", cap(buffer)))
+	}
+	pool.mu.Unlock()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, string(line), "drained")
+	assert.Equal(t, followLines(t, follower, 1), []string{"new"})
+	assert.Nil(t, follower.Close())
+	assert.Empty(t, pool.errs)
+	assert.Empty(t, pool.lent)
+}
+
+func TestFileFollower_Line_Removed(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log")
+	appendFile(t, path, "ab\n")
+	follower, err := FollowFile(path, 0, WithPollInterval(time.Millisecond))
+	assert.Nil(t, err)
+	defer follower.Close()
+	assert.Equal(t, followLines(t, follower, 1), []string{"ab"})
+
+	// when
+	assert.Nil(t, os.Remove(path))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = follower.Line(ctx)
+
+	// then
+	assert.Equal(t, err, context.DeadlineExceeded)
+
+	// when
+	appendFile(t, path, "cd\n")
+	lines := followLines(t, follower, 1)
+
+	// then
+	assert.Equal(t, lines, []string{"cd"})
+}
+
+func TestRotationKind_String(t *testing.T) {
+	assert.Equal(t, Truncated.String(), "truncated")
+	assert.Equal(t, Rotated.String(), "rotated")
+	assert.Equal(t, RotationKind(-1).String(), "unknown")
+}
@@ -0,0 +1,133 @@
+package linescanner
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+)
+
+// Follower scans forward like tail -f: at the end of the reader it keeps any unterminated
+// line and polls until the reader grows, so it only ever returns complete lines.
+type Follower struct {
+	forward      *forward
+	pollInterval time.Duration
+	err          error
+}
+
+func NewFollower(reader io.ReaderAt, position int, opts ...Option) *Follower {
+	return NewFollower64(reader, int64(position), opts...)
+}
+
+func NewFollower64(reader io.ReaderAt, position int64, opts ...Option) *Follower {
+	f, err := newFollower(reader, position, opts)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TryNewFollower(reader io.ReaderAt, position int, opts ...Option) (*Follower, error) {
+	return TryNewFollower64(reader, int64(position), opts...)
+}
+
+func TryNewFollower64(reader io.ReaderAt, position int64, opts ...Option) (*Follower, error) {
+	f, err := newFollower(reader, position, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePosition(reader, position); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func newFollower(reader io.ReaderAt, position int64, opts []Option) (*Follower, error) {
+	forward, err := newForward(reader, position, opts)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 {
+		return nil, ErrInvalidPosition
+	}
+	if forward.split != nil {
+		return nil, ErrSplitFuncUnsupported
+	}
+	if forward.longLinePolicy != LongLineError {
+		return nil, ErrInvalidLongLinePolicy
+	}
+	o := newOptions(opts)
+	if err := o.validateFollow(); err != nil {
+		return nil, err
+	}
+	return &Follower{
+		forward:      forward,
+		pollInterval: o.pollInterval,
+	}, nil
+}
+
+func waitPoll(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (f *Follower) Line(ctx context.Context) (string, error) {
+	line, err := f.LineBytes(ctx)
+	return string(line), err
+}
+
+func (f *Follower) LineBytes(ctx context.Context) ([]byte, error) {
+	for {
+		if f.err = ctx.Err(); f.err != nil {
+			return nil, f.err
+		}
+		line, err := f.forward.LineBytes()
+		if err != io.EOF {
+			f.err = err
+			return line, err
+		}
+		f.forward.resume()
+		if f.err = waitPoll(ctx, f.pollInterval); f.err != nil {
+			return nil, f.err
+		}
+	}
+}
+
+func (f *Follower) LineWithRange(ctx context.Context) (string, LineInfo, error) {
+	line, err := f.LineBytes(ctx)
+	return string(line), f.forward.info, err
+}
+
+func (f *Follower) Position() int {
+	return int(f.forward.readerLineStartPos)
+}
+
+func (f *Follower) Position64() int64 {
+	return f.forward.readerLineStartPos
+}
+
+func (f *Follower) Err() error {
+	return f.err
+}
+
+func (f *Follower) Lines(ctx context.Context) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for {
+			line, err := f.LineBytes(ctx)
+			if err != nil || !yield(string(line)) {
+				return
+			}
+		}
+	}
+}
+
+// Close stops prefetching. It is a no-op for followers without WithPrefetch.
+func (f *Follower) Close() error {
+	return f.forward.Close()
+}
@@ -0,0 +1,188 @@
+package linescanner
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type growingReader struct {
+	mu    sync.Mutex
+	data  []byte
+	reads int
+}
+
+func (r *growingReader) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	return strings.NewReader(string(r.data)).ReadAt(p, off)
+}
+
+func (r *growingReader) Append(data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = append(r.data, data...)
+}
+
+func TestFollower_NewFollower(t *testing.T) {
+	// given
+	reader := strings.NewReader("")
+
+	// when
+	follower := NewFollower(reader, 0, WithPollInterval(time.Millisecond))
+
+	// then
+	assert.Equal(t, follower.forward.reader, reader)
+	assert.Equal(t, follower.pollInterval, time.Millisecond)
+	assert.Equal(t, follower.Position64(), int64(0))
+}
+
+func TestFollower_NewFollower_Errors(t *testing.T) {
+	// given
+	tests := []struct {
+		reader   io.ReaderAt
+		position int
+		opts     []Option
+		err      error
+	}{
+		{nil, 0, nil, ErrNilReader},
+		{strings.NewReader(""), endPosition, nil, ErrInvalidPosition},
+		{strings.NewReader(""), 1, nil, ErrInvalidPosition},
+		{strings.NewReader(""), 0, []Option{WithPollInterval(0)}, ErrInvalidPollInterval},
+		{strings.NewReader(""), 0, []Option{WithSplitFunc(bufio.ScanWords)}, ErrSplitFuncUnsupported},
+		{strings.NewReader(""), 0, []Option{WithLongLinePolicy(LongLineSkip)}, ErrInvalidLongLinePolicy},
+	}
+
+	for _, test := range tests {
+		// when
+		follower, err := TryNewFollower(test.reader, test.position, test.opts...)
+
+		// then
+		assert.Nil(t, follower)
+		assert.Equal(t, err, test.err)
+	}
+}
+
+func TestFollower_Line(t *testing.T) {
+	// given
+	reader := &growingReader{data: []byte("ab\ncd")}
+	follower := NewFollower(reader, 0, WithChunkSize(2), WithMaxBufferSize(8), WithPollInterval(time.Millisecond))
+
+	// when
+	line, err := follower.Line(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, follower.Position(), 3)
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	line, err = follower.Line(ctx)
+
+	// then
+	assert.Equal(t, err, context.DeadlineExceeded)
+	assert.Equal(t, follower.Err(), context.DeadlineExceeded)
+	assert.Empty(t, line)
+	assert.Equal(t, follower.Position(), 3)
+
+	// when
+	reader.Append("\r")
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		reader.Append("\nef\n")
+	}()
+	line, info, err := follower.LineWithRange(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 3, End: 5, CarriageReturn: true, Terminated: true})
+
+	// when
+	line, err = follower.Line(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ef")
+	assert.Equal(t, follower.Position(), 10)
+	assert.Nil(t, follower.Err())
+}
+
+func TestFollower_Line_KeepsPartialLine(t *testing.T) {
+	// given
+	reader := &growingReader{data: []byte("abcd")}
+	follower := NewFollower(reader, 0, WithChunkSize(4), WithMaxBufferSize(8), WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := follower.Line(ctx)
+	assert.Equal(t, err, context.DeadlineExceeded)
+
+	// when
+	reader.Append("\n")
+	reads := reader.reads
+	line, err := follower.Line(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "abcd")
+	assert.Equal(t, reader.reads, reads+1)
+}
+
+func TestFollower_Line_Canceled(t *testing.T) {
+	// given
+	follower := NewFollower(&growingReader{}, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	line, err := follower.Line(ctx)
+
+	// then
+	assert.Equal(t, err, context.Canceled)
+	assert.Empty(t, line)
+}
+
+func TestFollower_Line_ReadError(t *testing.T) {
+	// given
+	follower := NewFollower(strings.NewReader("abcdef"), 0, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	_, err := follower.Line(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	assert.Equal(t, follower.Err(), err)
+}
+
+func TestFollower_Lines(t *testing.T) {
+	// given
+	reader := &growingReader{data: []byte("ab\ncd\nef")}
+	follower := NewFollower(reader, 0, WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	var lines []string
+	for line := range follower.Lines(ctx) {
+		lines = append(lines, line)
+		if len(lines) == 2 {
+			reader.Append("\ngh\n")
+		}
+		if len(lines) == 4 {
+			cancel()
+		}
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ab", "cd", "ef", "gh"})
+	assert.Equal(t, follower.Err(), context.Canceled)
+}
@@ -1,50 +1,117 @@
 package linescanner
 
 import (
+	"bufio"
 	"bytes"
 	"io"
+	"iter"
 )
 
 type forward struct {
-	reader io.ReaderAt
+	reader     io.ReaderAt
+	prefetcher *prefetcher
 
-	maxChunkSize int
-	chunk        []byte
+	maxChunkSize      int
+	adaptiveChunkSize bool
+	readSize          int
+	chunk             []byte
+	stats             Stats
+	pool              BufferPool
 
 	maxBufferSize int
 	buffer        []byte
 
-	readerPos          int
-	readerLineStartPos int
+	delimiter          []byte
+	keepCarriageReturn bool
+	split              bufio.SplitFunc
+
+	longLinePolicy   LongLinePolicy
+	longLine         bool
+	longLineStartPos int64
+	truncated        []byte
+
+	readerPos          int64
+	readerLineStartPos int64
 	bufferLineStartPos int
 
-	err error
+	line   []byte
+	info   LineInfo
+	noLine bool
+	err    error
 }
 
-func NewForward(reader io.ReaderAt, position int) *forward {
-	return NewForwardWithSize(reader, position, defaultMaxChunkSize, defaultMaxBufferSize)
+func NewForward(reader io.ReaderAt, position int, opts ...Option) *forward {
+	return NewForward64(reader, int64(position), opts...)
+}
+
+func NewForward64(reader io.ReaderAt, position int64, opts ...Option) *forward {
+	f, err := newForward(reader, position, opts)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TryNewForward(reader io.ReaderAt, position int, opts ...Option) (*forward, error) {
+	return TryNewForward64(reader, int64(position), opts...)
+}
+
+func TryNewForward64(reader io.ReaderAt, position int64, opts ...Option) (*forward, error) {
+	f, err := newForward(reader, position, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePosition(reader, position); err != nil {
+		return nil, err
+	}
+	return f, nil
 }
 
 func NewForwardWithSize(reader io.ReaderAt, position int, maxChunkSize int, maxBufferSize int) *forward {
-	if reader == nil {
-		panic(ErrNilReader)
+	return NewForward(reader, position, WithChunkSize(maxChunkSize), WithMaxBufferSize(maxBufferSize))
+}
+
+func NewForwardWithDelimiter(reader io.ReaderAt, position int, delimiter []byte) *forward {
+	return NewForward(reader, position, WithDelimiter(delimiter))
+}
+
+func NewForwardWithSplit(reader io.ReaderAt, position int, split bufio.SplitFunc) *forward {
+	if split == nil {
+		panic(ErrNilSplitFunc)
 	}
-	if maxChunkSize <= 0 {
-		panic(ErrInvalidMaxChunkSize)
+	return NewForward(reader, position, WithSplitFunc(split))
+}
+
+func newForward(reader io.ReaderAt, position int64, opts []Option) (*forward, error) {
+	if reader == nil {
+		return nil, ErrNilReader
 	}
-	if maxBufferSize <= 0 {
-		panic(ErrInvalidMaxBufferSize)
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
 	}
-	if maxChunkSize > maxBufferSize {
-		panic(ErrGreaterBufferSize)
+	if err := o.validateReads(); err != nil {
+		return nil, err
 	}
-	return &forward{
+	f := &forward{
 		reader:             reader,
-		maxChunkSize:       maxChunkSize,
-		maxBufferSize:      maxBufferSize,
+		maxChunkSize:       o.maxChunkSize,
+		adaptiveChunkSize:  o.adaptiveChunkSize,
+		readSize:           o.minChunkSize,
+		pool:               o.bufferPool,
+		maxBufferSize:      o.maxBufferSize,
+		delimiter:          o.delimiter,
+		keepCarriageReturn: !o.trimCarriageReturn(),
+		split:              o.split,
+		longLinePolicy:     o.longLinePolicy,
 		readerPos:          position,
 		readerLineStartPos: position,
 	}
+	if o.prefetch > 0 {
+		f.prefetcher = newPrefetcher(reader, Forward, o.maxChunkSize, o.adaptiveChunkSize, o.prefetch)
+		f.reader = f.prefetcher
+	}
+	return f, nil
 }
 
 func (f *forward) endOfFile() bool {
@@ -56,14 +123,20 @@ func (f *forward) endOfScan() bool {
 }
 
 func (f *forward) allocateChunk() error {
-	if f.chunk == nil {
-		f.chunk = make([]byte, f.maxChunkSize)
-	} else {
-		f.chunk = f.chunk[:f.maxChunkSize]
+	chunkSize := f.maxChunkSize
+	if f.adaptiveChunkSize {
+		chunkSize = f.readSize
+		f.readSize = growChunkSize(f.readSize, f.maxChunkSize)
 	}
-	n, err := f.reader.ReadAt(f.chunk, int64(f.readerPos))
+	if cap(f.chunk) < chunkSize {
+		release(f.pool, f.chunk)
+		f.chunk = allocate(f.pool, chunkSize)
+	}
+	f.chunk = f.chunk[:chunkSize]
+	n, err := f.reader.ReadAt(f.chunk, f.readerPos)
+	f.stats.add(chunkSize, n)
 	if err == nil {
-		f.readerPos += len(f.chunk)
+		f.readerPos += int64(len(f.chunk))
 	} else {
 		if err != io.EOF {
 			return err
@@ -84,8 +157,9 @@ func (f *forward) allocateBuffer() error {
 		return ErrBufferOverflow
 	}
 	if bufferLineSize+chunkSize > cap(f.buffer) {
-		expandedBuffer := make([]byte, 0, bufferLineSize+chunkSize)
+		expandedBuffer := allocate(f.pool, bufferLineSize+chunkSize)[:0]
 		expandedBuffer = append(expandedBuffer, f.buffer[f.bufferLineStartPos:]...)
+		release(f.pool, f.buffer)
 		f.buffer = expandedBuffer
 		f.bufferLineStartPos = 0
 	} else if f.bufferLineStartPos+bufferLineSize+chunkSize > cap(f.buffer) {
@@ -97,47 +171,381 @@ func (f *forward) allocateBuffer() error {
 	return nil
 }
 
-func (f *forward) removeLineFromBuffer(lineSize int) string {
-	line := removeCarriageReturn(f.buffer[f.bufferLineStartPos : f.bufferLineStartPos+lineSize])
-	f.readerLineStartPos += lineSize + 1
-	f.bufferLineStartPos += lineSize + 1
+func (f *forward) removeLineFromBuffer(lineSize int) []byte {
+	line := f.buffer[f.bufferLineStartPos : f.bufferLineStartPos+lineSize]
+	f.info = LineInfo{
+		Start:      f.readerLineStartPos,
+		End:        f.readerLineStartPos + int64(lineSize),
+		Terminated: true,
+	}
+	if !f.keepCarriageReturn {
+		if trimmed := trimCarriageReturn(line); len(trimmed) < len(line) {
+			line = trimmed
+			f.info.End--
+			f.info.CarriageReturn = true
+		}
+	}
+	f.readerLineStartPos += int64(lineSize + len(f.delimiter))
+	f.bufferLineStartPos += lineSize + len(f.delimiter)
 	return line
 }
 
-func (f *forward) read() (err error) {
-	if err = f.allocateChunk(); err != nil {
-		return err
+// maxFragmentSize leaves room for a delimiter and a carriage return in a buffer of bufferSize bytes.
+func (f *forward) maxFragmentSize(bufferSize int) int {
+	fragmentSize := bufferSize - len(f.delimiter) + 1
+	if !f.keepCarriageReturn {
+		fragmentSize--
+	}
+	return fragmentSize
+}
+
+func (f *forward) removeFragmentFromBuffer() []byte {
+	pending := f.buffer[f.bufferLineStartPos:]
+	fragmentSize := minInt(f.maxFragmentSize(f.maxBufferSize), len(pending))
+	if fragmentSize <= 0 {
+		fragmentSize = len(pending)
+	}
+	if !f.longLine {
+		f.longLine = true
+		f.longLineStartPos = f.readerLineStartPos
+	}
+	f.info = LineInfo{
+		Start: f.readerLineStartPos,
+		End:   f.readerLineStartPos + int64(fragmentSize),
+	}
+	f.readerLineStartPos += int64(fragmentSize)
+	f.bufferLineStartPos += fragmentSize
+	return pending[:fragmentSize]
+}
+
+func (f *forward) bufferFull() bool {
+	return f.longLinePolicy != LongLineError && len(f.buffer[f.bufferLineStartPos:]) >= f.maxBufferSize
+}
+
+// longLastLine reports whether the last line, which has no delimiter, would not fit in the buffer with one.
+// Backward finds the delimiter before a line in the same buffer, so a line is long in both directions when
+// it does not fit with a delimiter, wherever it is.
+func (f *forward) longLastLine() bool {
+	return f.longLinePolicy != LongLineError && !f.longLine &&
+		len(f.buffer[f.bufferLineStartPos:])+len(f.delimiter) > f.maxBufferSize
+}
+
+func (f *forward) handleLongLine() ([]byte, bool) {
+	first := !f.longLine
+	fragment := f.removeFragmentFromBuffer()
+	switch f.longLinePolicy {
+	case LongLineFragment:
+		f.info.Continued = true
+		return fragment, true
+	case LongLineTruncate:
+		if first {
+			f.truncated = append(f.truncated[:0], fragment...)
+		}
+	}
+	return nil, false
+}
+
+func (f *forward) completeLine(line []byte) ([]byte, bool) {
+	if !f.longLine {
+		return line, true
+	}
+	f.longLine = false
+	switch f.longLinePolicy {
+	case LongLineTruncate:
+		f.info.Start = f.longLineStartPos
+		f.info.Truncated = true
+		return f.truncated, true
+	case LongLineSkip:
+		return nil, false
+	}
+	return line, true
+}
+
+func (f *forward) fitChunk(chunkOffset int64) {
+	if f.longLinePolicy == LongLineError {
+		return
+	}
+	available := f.maxBufferSize - len(f.buffer[f.bufferLineStartPos:])
+	if len(f.chunk) > available {
+		f.chunk = f.chunk[:available]
+		f.readerPos = chunkOffset + int64(available)
+	}
+}
+
+func (f *forward) removeTokenFromBuffer(advance int, token []byte) {
+	data := f.buffer[f.bufferLineStartPos:]
+	f.info = LineInfo{
+		Start:      f.readerLineStartPos,
+		End:        f.readerLineStartPos + int64(advance),
+		Terminated: true,
+	}
+	if tokenPos, ok := tokenOffset(data, token); ok {
+		f.info.Start += int64(tokenPos)
+		f.info.End = f.info.Start + int64(len(token))
+		f.info.Terminated = tokenPos+len(token) < advance
+	}
+	f.skipBuffer(advance)
+}
+
+func (f *forward) skipBuffer(advance int) {
+	f.readerLineStartPos += int64(advance)
+	f.bufferLineStartPos += advance
+}
+
+func (f *forward) splitToken(data []byte) (advance int, token []byte, err error) {
+	advance, token, err = f.split(data, f.endOfFile())
+	if err != nil && err != bufio.ErrFinalToken {
+		return 0, nil, err
+	}
+	if advance < 0 {
+		return 0, nil, bufio.ErrNegativeAdvance
+	}
+	if advance > len(data) {
+		return 0, nil, bufio.ErrAdvanceTooFar
+	}
+	return advance, token, err
+}
+
+func (f *forward) hasNextToken() bool {
+	for {
+		data := f.buffer[f.bufferLineStartPos:]
+		if len(data) == 0 {
+			return false
+		}
+		advance, token, err := f.splitToken(data)
+		if err == bufio.ErrFinalToken {
+			return token != nil
+		}
+		if err != nil || token != nil {
+			return true
+		}
+		if advance == 0 {
+			return false
+		}
+		f.skipBuffer(advance)
+	}
+}
+
+func (f *forward) splitLineBytes() ([]byte, error) {
+	for {
+		advance, token, err := f.splitToken(f.buffer[f.bufferLineStartPos:])
+		if err == bufio.ErrFinalToken {
+			f.removeTokenFromBuffer(advance, token)
+			f.readerPos = endPosition
+			f.readerLineStartPos = endPosition
+			f.noLine = token == nil
+			return token, io.EOF
+		}
+		if err != nil {
+			f.err = f.newScanError(opSplit, f.readerLineStartPos, err)
+			return nil, f.err
+		}
+		if token != nil {
+			f.removeTokenFromBuffer(advance, token)
+			if f.endOfFile() && !f.hasNextToken() {
+				f.readerLineStartPos = endPosition
+				return token, io.EOF
+			}
+			return token, nil
+		}
+		if advance > 0 {
+			f.removeTokenFromBuffer(advance, nil)
+			continue
+		}
+		if f.endOfFile() {
+			f.readerLineStartPos = endPosition
+			f.noLine = true
+			return nil, io.EOF
+		}
+		if f.err = f.read(); f.err != nil {
+			return nil, f.err
+		}
+	}
+}
+
+func (f *forward) newScanError(op string, chunkOffset int64, err error) *ScanError {
+	return &ScanError{
+		Op:          op,
+		Direction:   Forward,
+		Offset:      f.readerLineStartPos,
+		ChunkOffset: chunkOffset,
+		Err:         err,
+	}
+}
+
+func (f *forward) read() error {
+	chunkOffset := f.readerPos
+	if err := f.allocateChunk(); err != nil {
+		return f.newScanError(opRead, chunkOffset, err)
 	}
+	f.fitChunk(chunkOffset)
 	if err := f.allocateBuffer(); err != nil {
-		return err
+		return f.newScanError(opBuffer, chunkOffset, err)
 	}
 	return nil
 }
 
+// resume continues a scan that returned its unterminated final line with io.EOF,
+// keeping that line in the buffer so bytes appended to the reader complete it.
+func (f *forward) resume() {
+	lineSize := int(f.info.End - f.info.Start)
+	if f.info.CarriageReturn {
+		lineSize++
+	}
+	f.bufferLineStartPos = len(f.buffer) - lineSize
+	f.readerLineStartPos = f.info.Start
+	f.readerPos = f.info.Start + int64(lineSize)
+	f.info = LineInfo{}
+}
+
 func (f *forward) Line() (string, error) {
+	line, err := f.LineBytes()
+	return string(line), err
+}
+
+func (f *forward) LineBytes() ([]byte, error) {
 	if f.err != nil {
-		return "", f.err
+		return nil, f.err
 	}
+	f.info = LineInfo{}
+	f.noLine = false
 	if f.endOfScan() {
-		return "", io.EOF
+		return nil, io.EOF
+	}
+	if f.split != nil {
+		return f.splitLineBytes()
 	}
 	for {
-		lineSize := bytes.IndexByte(f.buffer[f.bufferLineStartPos:], '\n')
+		lineSize := bytes.Index(f.buffer[f.bufferLineStartPos:], f.delimiter)
 		if lineSize >= 0 {
-			return f.removeLineFromBuffer(lineSize), nil
+			if line, ok := f.completeLine(f.removeLineFromBuffer(lineSize)); ok {
+				return line, nil
+			}
 		} else {
 			if f.endOfFile() {
+				if f.longLastLine() {
+					if line, ok := f.handleLongLine(); ok {
+						return line, nil
+					}
+					continue
+				}
 				line := f.removeLineFromBuffer(len(f.buffer[f.bufferLineStartPos:]))
+				f.info.Terminated = false
+				line, ok := f.completeLine(line)
 				f.readerLineStartPos = endPosition
+				f.noLine = !ok
 				return line, io.EOF
 			}
+			if f.bufferFull() {
+				if line, ok := f.handleLongLine(); ok {
+					return line, nil
+				}
+				continue
+			}
 			if f.err = f.read(); f.err != nil {
-				return "", f.err
+				return nil, f.err
 			}
 		}
 	}
 }
 
+func (f *forward) LineWithRange() (string, LineInfo, error) {
+	line, err := f.LineBytes()
+	return string(line), f.info, err
+}
+
 func (f *forward) Position() int {
+	return int(f.readerLineStartPos)
+}
+
+func (f *forward) Position64() int64 {
 	return f.readerLineStartPos
 }
+
+func (f *forward) Scan() bool {
+	if f.err != nil || f.endOfScan() {
+		f.line = nil
+		return false
+	}
+	line, err := f.LineBytes()
+	if err != nil && err != io.EOF {
+		f.line = nil
+		return false
+	}
+	if err == io.EOF && (f.noLine || f.split == nil && trailingLine(line, f.info)) {
+		f.line = nil
+		return false
+	}
+	f.line = line
+	return true
+}
+
+func (f *forward) Text() string {
+	return string(f.line)
+}
+
+func (f *forward) Bytes() []byte {
+	return f.line
+}
+
+func (f *forward) Truncated() bool {
+	return f.info.Truncated
+}
+
+func (f *forward) Continued() bool {
+	return f.info.Continued
+}
+
+func (f *forward) Err() error {
+	return f.err
+}
+
+func (f *forward) Lines() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for f.Scan() {
+			if !yield(f.Text()) {
+				return
+			}
+		}
+	}
+}
+
+func (f *forward) LinesWithPosition() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for f.Scan() {
+			if !yield(int(f.info.Start), f.Text()) {
+				return
+			}
+		}
+	}
+}
+
+func (f *forward) LinesWithPosition64() iter.Seq2[int64, string] {
+	return func(yield func(int64, string) bool) {
+		for f.Scan() {
+			if !yield(f.info.Start, f.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// Close stops prefetching and returns the buffers of a scanner with WithBufferPool. The scanner
+// cannot be used after Close.
+func (f *forward) Close() error {
+	if f.err == nil {
+		f.err = ErrScannerClosed
+	}
+	release(f.pool, f.chunk)
+	release(f.pool, f.buffer)
+	f.chunk, f.buffer, f.line = nil, nil, nil
+	f.bufferLineStartPos = 0
+	if f.prefetcher == nil {
+		return nil
+	}
+	return f.prefetcher.Close()
+}
+
+func (f *forward) Stats() Stats {
+	return f.stats
+}
@@ -1,6 +1,7 @@
 package linescanner
 
 import (
+	"bufio"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,8 +20,8 @@ func TestForward_NewForward(t *testing.T) {
 
 	// then
 	assert.Equal(t, scanner.reader, reader)
-	assert.Equal(t, scanner.readerPos, position)
-	assert.Equal(t, scanner.readerLineStartPos, position)
+	assert.Equal(t, scanner.readerPos, int64(position))
+	assert.Equal(t, scanner.readerLineStartPos, int64(position))
 	assert.Equal(t, scanner.bufferLineStartPos, 0)
 	assert.Equal(t, scanner.maxChunkSize, defaultMaxChunkSize)
 	assert.Equal(t, scanner.maxBufferSize, defaultMaxBufferSize)
@@ -108,7 +109,7 @@ func TestForward_AllocateChunk(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, forward.chunk, []byte("efgh"))
 	assert.Equal(t, cap(forward.chunk), 4)
-	assert.Equal(t, forward.readerPos, 8)
+	assert.Equal(t, forward.readerPos, int64(8))
 }
 
 func TestForward_AllocateChunk_AlreadyAllocated(t *testing.T) {
@@ -124,7 +125,7 @@ func TestForward_AllocateChunk_AlreadyAllocated(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, forward.chunk, []byte("efgh"))
 	assert.Equal(t, cap(forward.chunk), 4)
-	assert.Equal(t, forward.readerPos, 8)
+	assert.Equal(t, forward.readerPos, int64(8))
 }
 
 func TestForward_AllocateChunk_WithPosition(t *testing.T) {
@@ -138,7 +139,7 @@ func TestForward_AllocateChunk_WithPosition(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, forward.chunk, []byte("cdef"))
 	assert.Equal(t, cap(forward.chunk), 4)
-	assert.Equal(t, forward.readerPos, 6)
+	assert.Equal(t, forward.readerPos, int64(6))
 }
 
 func TestForward_AllocateChunk_ReadError(t *testing.T) {
@@ -153,7 +154,7 @@ func TestForward_AllocateChunk_ReadError(t *testing.T) {
 
 	// then
 	assert.Equal(t, err, readErr)
-	assert.Equal(t, forward.readerPos, 10)
+	assert.Equal(t, forward.readerPos, int64(10))
 }
 
 func TestForward_AllocateChunk_EndOfFile(t *testing.T) {
@@ -271,15 +272,15 @@ func TestForward_RemoveLineFromBuffer(t *testing.T) {
 	lineSize := 6
 	forward := NewForwardWithSize(strings.NewReader(""), 0, 4, 4)
 	forward.buffer = []byte("ab\ncdefg\r\n")
-	forward.readerLineStartPos = readerLineStartPos
+	forward.readerLineStartPos = int64(readerLineStartPos)
 	forward.bufferLineStartPos = bufferLineStartPos
 
 	// when
 	line := forward.removeLineFromBuffer(lineSize)
 
 	// then
-	assert.Equal(t, line, "cdefg")
-	assert.Equal(t, forward.readerLineStartPos, readerLineStartPos+lineSize+1)
+	assert.Equal(t, line, []byte("cdefg"))
+	assert.Equal(t, forward.readerLineStartPos, int64(readerLineStartPos+lineSize+1))
 	assert.Equal(t, forward.bufferLineStartPos, bufferLineStartPos+lineSize+1)
 }
 
@@ -296,7 +297,7 @@ func TestForward_Read(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("abcd"))
 	assert.Equal(t, cap(forward.buffer), 4)
-	assert.Equal(t, forward.readerPos, 4)
+	assert.Equal(t, forward.readerPos, int64(4))
 	assert.False(t, forward.endOfFile())
 
 	// when
@@ -308,7 +309,7 @@ func TestForward_Read(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("abcd\nefg"))
 	assert.Equal(t, cap(forward.buffer), 8)
-	assert.Equal(t, forward.readerPos, 8)
+	assert.Equal(t, forward.readerPos, int64(8))
 	assert.False(t, forward.endOfFile())
 
 	// when
@@ -320,7 +321,7 @@ func TestForward_Read(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("abcd\nefgh\nij"))
 	assert.Equal(t, cap(forward.buffer), 12)
-	assert.Equal(t, forward.readerPos, 12)
+	assert.Equal(t, forward.readerPos, int64(12))
 	assert.False(t, forward.endOfFile())
 
 	// when
@@ -345,8 +346,8 @@ func TestForward_Read_AllocateChunkError(t *testing.T) {
 	err := forward.read()
 
 	// then
-	assert.Equal(t, err, readErr)
-	assert.Equal(t, forward.readerPos, 10)
+	assert.ErrorIs(t, err, readErr)
+	assert.Equal(t, forward.readerPos, int64(10))
 }
 
 func TestForward_Read_AllocateChunkEndOfFile(t *testing.T) {
@@ -376,7 +377,7 @@ func TestForward_Read_AllocateBufferError(t *testing.T) {
 	err := forward.read()
 
 	// then
-	assert.Equal(t, err, ErrBufferOverflow)
+	assert.ErrorIs(t, err, ErrBufferOverflow)
 	assert.Equal(t, forward.chunk, []byte("g"))
 	assert.Equal(t, forward.buffer, []byte(buffer))
 	assert.True(t, forward.endOfFile())
@@ -397,8 +398,8 @@ func TestForward_Line(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("a\nb\r"))
 	assert.Equal(t, cap(forward.buffer), 4)
-	assert.Equal(t, forward.readerPos, 4)
-	assert.Equal(t, forward.readerLineStartPos, 2)
+	assert.Equal(t, forward.readerPos, int64(4))
+	assert.Equal(t, forward.readerLineStartPos, int64(2))
 	assert.Equal(t, forward.bufferLineStartPos, 2)
 	assert.False(t, forward.endOfFile())
 	assert.False(t, forward.endOfScan())
@@ -413,8 +414,8 @@ func TestForward_Line(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("b\r\ncde"))
 	assert.Equal(t, cap(forward.buffer), 6)
-	assert.Equal(t, forward.readerPos, 8)
-	assert.Equal(t, forward.readerLineStartPos, 5)
+	assert.Equal(t, forward.readerPos, int64(8))
+	assert.Equal(t, forward.readerLineStartPos, int64(5))
 	assert.Equal(t, forward.bufferLineStartPos, 3)
 	assert.False(t, forward.endOfFile())
 	assert.False(t, forward.endOfScan())
@@ -429,8 +430,8 @@ func TestForward_Line(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("cdef\ngh"))
 	assert.Equal(t, cap(forward.buffer), 7)
-	assert.Equal(t, forward.readerPos, 12)
-	assert.Equal(t, forward.readerLineStartPos, 10)
+	assert.Equal(t, forward.readerPos, int64(12))
+	assert.Equal(t, forward.readerLineStartPos, int64(10))
 	assert.Equal(t, forward.bufferLineStartPos, 5)
 	assert.False(t, forward.endOfFile())
 	assert.False(t, forward.endOfScan())
@@ -445,8 +446,8 @@ func TestForward_Line(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("gh\ni"))
 	assert.Equal(t, cap(forward.buffer), 7)
-	assert.Equal(t, forward.readerPos, endPosition)
-	assert.Equal(t, forward.readerLineStartPos, 13)
+	assert.Equal(t, forward.readerPos, int64(endPosition))
+	assert.Equal(t, forward.readerLineStartPos, int64(13))
 	assert.Equal(t, forward.bufferLineStartPos, 3)
 	assert.True(t, forward.endOfFile())
 	assert.False(t, forward.endOfScan())
@@ -461,8 +462,8 @@ func TestForward_Line(t *testing.T) {
 	assert.Equal(t, cap(forward.chunk), 4)
 	assert.Equal(t, forward.buffer, []byte("gh\ni"))
 	assert.Equal(t, cap(forward.buffer), 7)
-	assert.Equal(t, forward.readerPos, endPosition)
-	assert.Equal(t, forward.readerLineStartPos, endPosition)
+	assert.Equal(t, forward.readerPos, int64(endPosition))
+	assert.Equal(t, forward.readerLineStartPos, int64(endPosition))
 	assert.Equal(t, forward.bufferLineStartPos, 5)
 	assert.True(t, forward.endOfFile())
 	assert.True(t, forward.endOfScan())
@@ -479,14 +480,14 @@ func TestForward_Line_Error(t *testing.T) {
 	line, err := forward.Line()
 
 	// then
-	assert.Equal(t, err, readErr)
+	assert.ErrorIs(t, err, readErr)
 	assert.Empty(t, line)
 
 	// when
 	line, err = forward.Line()
 
 	// then
-	assert.Equal(t, err, readErr)
+	assert.ErrorIs(t, err, readErr)
 	assert.Empty(t, line)
 }
 
@@ -564,3 +565,912 @@ func TestForward_Position(t *testing.T) {
 	assert.Equal(t, line, "hij")
 	assert.Equal(t, forward.Position(), endPosition)
 }
+
+func TestForward_LineBytes(t *testing.T) {
+	// given
+	data := "ab\r\ncd"
+	forward := NewForward(strings.NewReader(data), 0)
+
+	// when
+	line, err := forward.LineBytes()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, []byte("ab"))
+	assert.Equal(t, &line[0], &forward.buffer[0])
+
+	// when
+	line, err = forward.LineBytes()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, []byte("cd"))
+	assert.Equal(t, forward.Position(), endPosition)
+
+	// when
+	line, err = forward.LineBytes()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Nil(t, line)
+}
+
+func TestForward_LineBytes_Error(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	var scanner ByteLineScanner = NewForward(reader, 0)
+
+	// when
+	line, err := scanner.LineBytes()
+
+	// then
+	assert.ErrorIs(t, err, readErr)
+	assert.Nil(t, line)
+}
+
+func TestForward_Scan(t *testing.T) {
+	// given
+	data := "a\nb\r\n\nc"
+	forward := NewForwardWithSize(strings.NewReader(data), 0, 2, 4)
+
+	// when
+	var lines []string
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"a", "b", "", "c"})
+	assert.Nil(t, forward.Bytes())
+	assert.False(t, forward.Scan())
+}
+
+func TestForward_Scan_TrailingDelimiter(t *testing.T) {
+	tests := []struct {
+		data      string
+		delimiter []byte
+		lines     []string
+	}{
+		{"a\nb\n", []byte("\n"), []string{"a", "b"}},
+		{"a\r\nb\r\n", []byte("\n"), []string{"a", "b"}},
+		{"a\n\n", []byte("\n"), []string{"a", ""}},
+		{"\n", []byte("\n"), []string{""}},
+		{"", []byte("\n"), nil},
+		{"a\x00b\x00", []byte{0}, []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		// given
+		forward := NewForward(strings.NewReader(test.data), 0, WithDelimiter(test.delimiter), WithChunkSize(2), WithMaxBufferSize(4))
+
+		// when
+		var lines []string
+		for forward.Scan() {
+			lines = append(lines, forward.Text())
+		}
+
+		// then
+		assert.Nil(t, forward.Err())
+		assert.Equal(t, lines, test.lines, test.data)
+	}
+}
+
+func TestForward_Scan_Bytes(t *testing.T) {
+	// given
+	forward := NewForward(strings.NewReader("ab\ncd"), 0)
+
+	// when
+	ok := forward.Scan()
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, forward.Bytes(), []byte("ab"))
+	assert.Equal(t, forward.Text(), "ab")
+}
+
+func TestForward_Scan_AlreadyEndOfScan(t *testing.T) {
+	// given
+	forward := NewForward(strings.NewReader("abc"), endPosition)
+
+	// when
+	ok := forward.Scan()
+
+	// then
+	assert.False(t, ok)
+	assert.Nil(t, forward.Err())
+}
+
+func TestForward_Scan_Error(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	forward := NewForward(reader, 0)
+
+	// when
+	ok := forward.Scan()
+
+	// then
+	assert.False(t, ok)
+	assert.ErrorIs(t, forward.Err(), readErr)
+	assert.Empty(t, forward.Text())
+}
+
+func TestForward_Lines(t *testing.T) {
+	// given
+	data := "a\nbc\r\nd"
+	forward := NewForwardWithSize(strings.NewReader(data), 0, 2, 4)
+
+	// when
+	var lines []string
+	for line := range forward.Lines() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"a", "bc", "d"})
+}
+
+func TestForward_Lines_Break(t *testing.T) {
+	// given
+	data := "a\nb\nc"
+	forward := NewForward(strings.NewReader(data), 0)
+
+	// when
+	var lines []string
+	for line := range forward.Lines() {
+		lines = append(lines, line)
+		break
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"a"})
+	assert.Equal(t, forward.Position(), 2)
+
+	// when
+	for line := range forward.Lines() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"a", "b", "c"})
+}
+
+func TestForward_LinesWithPosition(t *testing.T) {
+	// given
+	data := "a\nbc\r\nd"
+	forward := NewForward(strings.NewReader(data), 2)
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range forward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, positions, []int{2, 6})
+	assert.Equal(t, lines, []string{"bc", "d"})
+}
+
+func TestForward_Lines_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "a\nbc\r\n\nd\n"
+
+	// when
+	var lines []string
+	for line := range NewForward(strings.NewReader(data), 0).Lines() {
+		lines = append(lines, line)
+	}
+	var positions []int
+	for position := range NewForward(strings.NewReader(data), 0).LinesWithPosition() {
+		positions = append(positions, position)
+	}
+	var positions64 []int64
+	for position := range NewForward(strings.NewReader(data), 0).LinesWithPosition64() {
+		positions64 = append(positions64, position)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"a", "bc", "", "d"})
+	assert.Equal(t, positions, []int{0, 2, 6, 7})
+	assert.Equal(t, positions64, []int64{0, 2, 6, 7})
+}
+
+func TestForward_Lines_LeadingDelimiter(t *testing.T) {
+	// given
+	data := "\na\r\nb"
+
+	// when
+	var lines []string
+	for line := range NewForward(strings.NewReader(data), 0).Lines() {
+		lines = append(lines, line)
+	}
+	var positions64 []int64
+	for position := range NewForward(strings.NewReader(data), 0).LinesWithPosition64() {
+		positions64 = append(positions64, position)
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"", "a", "b"})
+	assert.Equal(t, positions64, []int64{0, 1, 4})
+}
+
+func TestForward_LinesWithPosition_BufferOverflow(t *testing.T) {
+	// given
+	data := "a\nbcdef\ng"
+	forward := NewForwardWithSize(strings.NewReader(data), 0, 2, 4)
+
+	// when
+	var lines []string
+	for _, line := range forward.LinesWithPosition() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.ErrorIs(t, forward.Err(), ErrBufferOverflow)
+	assert.Equal(t, lines, []string{"a"})
+}
+
+func TestForward_NewForwardWithDelimiter(t *testing.T) {
+	// given
+	delimiter := []byte{0}
+
+	// when
+	forward := NewForwardWithDelimiter(strings.NewReader(""), 0, delimiter)
+	delimiter[0] = 'x'
+
+	// then
+	assert.Equal(t, forward.delimiter, []byte{0})
+	assert.True(t, forward.keepCarriageReturn)
+	assert.Equal(t, forward.maxChunkSize, defaultMaxChunkSize)
+	assert.Equal(t, forward.maxBufferSize, defaultMaxBufferSize)
+}
+
+func TestForward_NewForwardWithDelimiter_ErrEmptyDelimiter(t *testing.T) {
+	assert.PanicsWithValue(t, ErrEmptyDelimiter, func() {
+		NewForwardWithDelimiter(strings.NewReader(""), 0, nil)
+	})
+}
+
+func TestForward_NewForwardWithSplit_ErrNilSplitFunc(t *testing.T) {
+	assert.PanicsWithValue(t, ErrNilSplitFunc, func() {
+		NewForwardWithSplit(strings.NewReader(""), 0, nil)
+	})
+}
+
+func TestForward_Line_SingleByteDelimiter(t *testing.T) {
+	// given
+	data := "a\r\x00bc\x00\x00d"
+	forward := NewForwardWithDelimiter(strings.NewReader(data), 0, []byte{0})
+
+	// when
+	var lines []string
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"a\r", "bc", "", "d"})
+}
+
+func TestForward_Line_MultiByteDelimiter(t *testing.T) {
+	// given
+	data := "ab--cde--f-g----"
+	forward := NewForwardWithDelimiter(strings.NewReader(data), 0, []byte("--"))
+	forward.maxChunkSize = 3
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range forward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"ab", "cde", "f-g", ""})
+	assert.Equal(t, positions, []int{0, 4, 9, 14})
+}
+
+func TestForward_Line_MultiByteDelimiterPosition(t *testing.T) {
+	// given
+	data := "ab\r\n\r\ncd"
+	forward := NewForwardWithDelimiter(strings.NewReader(data), 0, []byte("\r\n\r\n"))
+
+	// when
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, forward.Position(), 6)
+
+	// given
+	forward = NewForwardWithDelimiter(strings.NewReader(data), forward.Position(), []byte("\r\n\r\n"))
+
+	// when
+	line, err = forward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "cd")
+}
+
+func TestForward_Line_SplitFunc(t *testing.T) {
+	// given
+	data := "  ab c\n\tdef  "
+	forward := NewForwardWithSplit(strings.NewReader(data), 0, bufio.ScanWords)
+	forward.maxChunkSize = 2
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range forward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"ab", "c", "def"})
+	assert.Equal(t, positions, []int{2, 5, 8})
+	assert.True(t, forward.endOfScan())
+}
+
+func TestForward_Line_SplitFuncLastToken(t *testing.T) {
+	// given
+	data := "a\r\nb\n"
+	forward := NewForwardWithSplit(strings.NewReader(data), 0, bufio.ScanLines)
+
+	// when
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "a")
+	assert.Equal(t, forward.Position(), 3)
+
+	// when
+	line, err = forward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "b")
+	assert.Equal(t, forward.Position(), endPosition)
+}
+
+func TestForward_Line_SplitFuncEmpty(t *testing.T) {
+	// given
+	forward := NewForwardWithSplit(strings.NewReader(""), 0, bufio.ScanLines)
+
+	// when
+	ok := forward.Scan()
+
+	// then
+	assert.False(t, ok)
+	assert.Nil(t, forward.Err())
+	assert.True(t, forward.endOfScan())
+}
+
+func TestForward_Line_SplitFuncFinalToken(t *testing.T) {
+	// given
+	split := func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if string(token) == "stop" {
+			return advance, token, bufio.ErrFinalToken
+		}
+		return advance, token, err
+	}
+	forward := NewForwardWithSplit(strings.NewReader("a\nstop\nb\n"), 0, split)
+
+	// when
+	var lines []string
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"a", "stop"})
+}
+
+func TestForward_Line_SplitFuncError(t *testing.T) {
+	// given
+	splitErr := errors.New("")
+	split := func(data []byte, atEOF bool) (int, []byte, error) {
+		return 0, nil, splitErr
+	}
+	forward := NewForwardWithSplit(strings.NewReader("abc"), 0, split)
+
+	// when
+	line, err := forward.LineBytes()
+
+	// then
+	assert.ErrorIs(t, err, splitErr)
+	assert.Nil(t, line)
+
+	// when
+	line, err = forward.LineBytes()
+
+	// then
+	assert.ErrorIs(t, err, splitErr)
+	assert.Nil(t, line)
+}
+
+func TestForward_Line_SplitFuncAdvanceTooFar(t *testing.T) {
+	// given
+	split := func(data []byte, atEOF bool) (int, []byte, error) {
+		return len(data) + 1, nil, nil
+	}
+	forward := NewForwardWithSplit(strings.NewReader("abc"), 0, split)
+
+	// when
+	_, err := forward.Line()
+
+	// then
+	assert.ErrorIs(t, err, bufio.ErrAdvanceTooFar)
+}
+
+func TestForward_NewForward_WithOptions(t *testing.T) {
+	// given
+	data := "ab\r\ncd"
+
+	// when
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(8), WithKeepCarriageReturn())
+
+	// then
+	assert.Equal(t, forward.maxChunkSize, 2)
+	assert.Equal(t, forward.maxBufferSize, 8)
+	assert.True(t, forward.keepCarriageReturn)
+
+	// when
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab\r")
+}
+
+func TestForward_NewForward_InvalidOption(t *testing.T) {
+	assert.PanicsWithValue(t, ErrEmptyDelimiter, func() {
+		NewForward(strings.NewReader(""), 0, WithDelimiter(nil))
+	})
+}
+
+func TestForward_TryNewForward(t *testing.T) {
+	// given
+	reader := strings.NewReader("abcd")
+
+	// when
+	forward, err := TryNewForward(reader, 2, WithChunkSize(2))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, forward.reader, reader)
+	assert.Equal(t, forward.readerPos, int64(2))
+	assert.Equal(t, forward.maxChunkSize, 2)
+}
+
+func TestForward_TryNewForward_Error(t *testing.T) {
+	// given
+	tests := []struct {
+		reader   io.ReaderAt
+		position int
+		opts     []Option
+		err      error
+	}{
+		{nil, 0, nil, ErrNilReader},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(0)}, ErrInvalidMaxChunkSize},
+		{strings.NewReader(""), 0, []Option{WithMaxBufferSize(0)}, ErrInvalidMaxBufferSize},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(10), WithMaxBufferSize(5)}, ErrGreaterBufferSize},
+		{strings.NewReader(""), 0, []Option{WithAdaptiveChunkSize(0)}, ErrInvalidMinChunkSize},
+		{strings.NewReader(""), 0, []Option{WithChunkSize(8), WithAdaptiveChunkSize(16)}, ErrInvalidMinChunkSize},
+		{strings.NewReader("abcd"), 5, nil, ErrInvalidPosition},
+		{strings.NewReader("abcd"), -2, nil, ErrInvalidPosition},
+	}
+
+	for _, test := range tests {
+		// when
+		forward, err := TryNewForward(test.reader, test.position, test.opts...)
+
+		// then
+		assert.Equal(t, err, test.err)
+		assert.Nil(t, forward)
+	}
+}
+
+func TestForward_Line_ScanError(t *testing.T) {
+	// given
+	data := "ab\ncdefgh\nij"
+	forward := NewForwardWithSize(strings.NewReader(data), 0, 2, 4)
+
+	// when
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+
+	// when
+	_, err = forward.Line()
+
+	// then
+	var scanErr *ScanError
+	assert.True(t, errors.As(err, &scanErr))
+	assert.Equal(t, scanErr.Op, opBuffer)
+	assert.Equal(t, scanErr.Direction, Forward)
+	assert.Equal(t, scanErr.Offset, int64(3))
+	assert.Equal(t, scanErr.ChunkOffset, int64(6))
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestForward_Line_ScanErrorRead(t *testing.T) {
+	// given
+	readErr := errors.New("")
+	reader := new(ReaderMock)
+	reader.On("ReadAt", mock.Anything, mock.Anything).Return(0, readErr)
+	forward := NewForward(reader, 42)
+
+	// when
+	_, err := forward.Line()
+
+	// then
+	assert.Equal(t, err, &ScanError{Op: opRead, Direction: Forward, Offset: 42, ChunkOffset: 42, Err: readErr})
+}
+
+func TestForward_Line_LongLineTruncate(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+
+	// when
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.False(t, forward.Truncated())
+
+	// when
+	line, err = forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "abc")
+	assert.True(t, forward.Truncated())
+	assert.False(t, forward.Continued())
+	assert.Equal(t, forward.info.Start, int64(3))
+	assert.Equal(t, forward.Position(), 15)
+
+	// when
+	line, err = forward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "cd")
+	assert.False(t, forward.Truncated())
+}
+
+func TestForward_Line_LongLineTruncateAtEndOfFile(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+
+	// when
+	var lines []string
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"ab", "abc"})
+	assert.True(t, forward.Truncated())
+}
+
+func TestForward_Line_LongLineSkip(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\ncd\nefghijklmn"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineSkip))
+
+	// when
+	var positions []int
+	var lines []string
+	for position, line := range forward.LinesWithPosition() {
+		positions = append(positions, position)
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"ab", "cd"})
+	assert.Equal(t, positions, []int{0, 14})
+	assert.Equal(t, forward.Position(), endPosition)
+}
+
+func TestForward_Line_LongLineFragment(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+
+	// when
+	var lines []string
+	var continued []bool
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+		continued = append(continued, forward.Continued())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"ab", "abc", "def", "ghi", "j", "cd"})
+	assert.Equal(t, continued, []bool{false, true, true, true, false, false})
+}
+
+func TestForward_Line_LongLineFragmentMultiByteDelimiter(t *testing.T) {
+	// given
+	data := "abcdef--gh"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4),
+		WithDelimiter([]byte("--")), WithLongLinePolicy(LongLineFragment))
+
+	// when
+	var lines []string
+	for forward.Scan() {
+		lines = append(lines, forward.Text())
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, lines, []string{"abc", "def", "", "gh"})
+}
+
+func TestForward_Line_LongLineFitChunk(t *testing.T) {
+	// given
+	data := "abcdefg"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(3), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+	forward.buffer = []byte("abc")
+	forward.readerPos = 3
+	forward.readerLineStartPos = 0
+
+	// when
+	err := forward.read()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, forward.buffer, []byte("abcd"))
+	assert.Equal(t, forward.readerPos, int64(4))
+	assert.False(t, forward.endOfFile())
+}
+
+func TestForward_LineWithRange(t *testing.T) {
+	// given
+	data := "ab\r\ncd\nef"
+	forward := NewForwardWithSize(strings.NewReader(data), 0, 2, 4)
+
+	// when
+	line, info, err := forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, CarriageReturn: true, Terminated: true})
+
+	// when
+	line, info, err = forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	assert.Equal(t, info, LineInfo{Start: 4, End: 6, Terminated: true})
+
+	// when
+	line, info, err = forward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "ef")
+	assert.Equal(t, info, LineInfo{Start: 7, End: 9})
+
+	// when
+	line, info, err = forward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Empty(t, line)
+	assert.Equal(t, info, LineInfo{})
+}
+
+func TestForward_LineWithRange_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "ab\n"
+	forward := NewForward(strings.NewReader(data), 0)
+
+	// when
+	_, info, err := forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, info, LineInfo{Start: 0, End: 2, Terminated: true})
+
+	// when
+	line, info, err := forward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Empty(t, line)
+	assert.Equal(t, info, LineInfo{Start: 3, End: 3})
+}
+
+func TestForward_LineWithRange_LongLine(t *testing.T) {
+	// given
+	data := "abcdefghij\r\ncd"
+
+	// when
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineTruncate))
+	line, info, err := forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "abc")
+	assert.Equal(t, info, LineInfo{Start: 0, End: 10, CarriageReturn: true, Terminated: true, Truncated: true})
+
+	// when
+	forward = NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+	var infos []LineInfo
+	for {
+		_, info, err := forward.LineWithRange()
+		infos = append(infos, info)
+		if err != nil {
+			break
+		}
+	}
+
+	// then
+	assert.Equal(t, infos, []LineInfo{
+		{Start: 0, End: 3, Continued: true},
+		{Start: 3, End: 6, Continued: true},
+		{Start: 6, End: 9, Continued: true},
+		{Start: 9, End: 10, CarriageReturn: true, Terminated: true},
+		{Start: 12, End: 14},
+	})
+}
+
+func TestForward_LineWithRange_SplitFunc(t *testing.T) {
+	// given
+	data := "  ab c"
+	forward := NewForwardWithSplit(strings.NewReader(data), 0, bufio.ScanWords)
+
+	// when
+	line, info, err := forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: 2, End: 4, Terminated: true})
+
+	// when
+	line, info, err = forward.LineWithRange()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, line, "c")
+	assert.Equal(t, info, LineInfo{Start: 5, End: 6})
+}
+
+func TestForward_LineWithRange_SplitFuncTrailingSeparator(t *testing.T) {
+	tests := []struct {
+		data  string
+		lines []string
+		infos []LineInfo
+	}{
+		{"one  ", []string{"one"}, []LineInfo{{Start: 0, End: 3, Terminated: true}}},
+		{"ab cd\n", []string{"ab", "cd"}, []LineInfo{{Start: 0, End: 2, Terminated: true}, {Start: 3, End: 5, Terminated: true}}},
+		{"  ab c\n\tdef  ", []string{"ab", "c", "def"}, []LineInfo{{Start: 2, End: 4, Terminated: true}, {Start: 5, End: 6, Terminated: true}, {Start: 8, End: 11, Terminated: true}}},
+	}
+
+	for _, test := range tests {
+		// given
+		forward := NewForward(strings.NewReader(test.data), 0, WithSplitFunc(bufio.ScanWords))
+
+		// when
+		var lines []string
+		var infos []LineInfo
+		for {
+			line, info, err := forward.LineWithRange()
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			lines = append(lines, line)
+			infos = append(infos, info)
+			if err == io.EOF {
+				break
+			}
+		}
+
+		// then
+		assert.Equal(t, lines, test.lines, test.data)
+		assert.Equal(t, infos, test.infos, test.data)
+	}
+}
+
+func TestForward_LargeOffset(t *testing.T) {
+	// given
+	reader := newSparseReader(sparseOffset, "ab\ncd\nef")
+	forward, err := TryNewForward64(reader, sparseOffset)
+	assert.Nil(t, err)
+
+	// when
+	line, info, err := forward.LineWithRange()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	assert.Equal(t, info, LineInfo{Start: sparseOffset, End: sparseOffset + 2, Terminated: true})
+	assert.Equal(t, forward.Position64(), sparseOffset+3)
+
+	// when
+	var positions []int64
+	for position := range forward.LinesWithPosition64() {
+		positions = append(positions, position)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Equal(t, positions, []int64{sparseOffset + 3, sparseOffset + 6})
+	assert.Equal(t, forward.Position64(), int64(endPosition))
+}
+
+func TestForward_LargeOffset_ScanError(t *testing.T) {
+	// given
+	reader := newSparseReader(sparseOffset, "abcdef")
+	forward := NewForward64(reader, sparseOffset, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// when
+	_, err := forward.Line()
+
+	// then
+	var scanErr *ScanError
+	assert.ErrorAs(t, err, &scanErr)
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+	assert.Equal(t, scanErr.Offset, sparseOffset)
+	assert.Equal(t, scanErr.ChunkOffset, sparseOffset+4)
+}
+
+func TestForward_AdaptiveChunkSize(t *testing.T) {
+	// given
+	data := strings.Repeat("line\n", 40)
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(64), WithAdaptiveChunkSize(4))
+
+	// when
+	var lines []string
+	for line := range forward.Lines() {
+		lines = append(lines, line)
+	}
+
+	// then
+	assert.Nil(t, forward.Err())
+	assert.Len(t, lines, 40)
+	assert.Equal(t, forward.Stats(), Stats{Reads: 7, BytesRead: int64(len(data)), ChunkSize: 64, MaxChunkSize: 64})
+}
+
+func TestForward_Stats(t *testing.T) {
+	// given
+	data := "ab\ncd\n"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(4))
+
+	// when
+	_, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, forward.Stats(), Stats{Reads: 1, BytesRead: 4, ChunkSize: 4, MaxChunkSize: 4})
+}
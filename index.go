@@ -0,0 +1,179 @@
+package linescanner
+
+import (
+	"io"
+	"sort"
+)
+
+// Index records the start of every stride-th line of a reader, so a line can be reached
+// by scanning at most stride lines. Lines are numbered from 0.
+type Index struct {
+	reader io.ReaderAt
+	opts   []Option
+
+	delimiter      []byte
+	longLinePolicy LongLinePolicy
+
+	stride  int
+	offsets []int64
+	lines   int
+	size    int64
+
+	modTime  int64
+	checksum uint32
+}
+
+func NewIndex(reader io.ReaderAt, opts ...Option) (*Index, error) {
+	forward, err := newForward(reader, 0, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer forward.Close()
+	o := newOptions(opts)
+	if err := o.validateIndex(); err != nil {
+		return nil, err
+	}
+	index := &Index{
+		reader:         reader,
+		opts:           opts,
+		delimiter:      o.delimiter,
+		longLinePolicy: o.longLinePolicy,
+		stride:         o.indexStride,
+	}
+	if err := index.scan(forward, 0); err != nil {
+		return nil, err
+	}
+	if index.modTime, index.checksum, err = fingerprint(reader, index.size); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (x *Index) scan(forward *forward, firstLine int) error {
+	return forEachLine(forward, func(number int, info LineInfo, _ []byte) bool {
+		number += firstLine
+		if number%x.stride == 0 && len(x.offsets) == number/x.stride {
+			x.offsets = append(x.offsets, info.Start)
+		}
+		x.lines = number + 1
+		if forward.readerLineStartPos >= 0 {
+			x.size = forward.readerLineStartPos
+		} else {
+			x.size = lineEnd(info)
+		}
+		return true
+	})
+}
+
+// line scans from the closest indexed line to line n and returns its range.
+func (x *Index) line(n int) (LineInfo, error) {
+	block := n / x.stride
+	forward, err := newForward(x.reader, x.offsets[block], x.opts)
+	if err != nil {
+		return LineInfo{}, err
+	}
+	defer forward.Close()
+	var line LineInfo
+	found := false
+	err = forEachLine(forward, func(number int, info LineInfo, _ []byte) bool {
+		number += block * x.stride
+		if number < n {
+			return true
+		}
+		if number > n {
+			return false
+		}
+		line, found = info, true
+		return info.Continued
+	})
+	if err != nil {
+		return LineInfo{}, err
+	}
+	if !found {
+		return LineInfo{}, ErrLineOutOfRange
+	}
+	return line, nil
+}
+
+func (x *Index) Lines() int {
+	return x.lines
+}
+
+func (x *Index) Stride() int {
+	return x.stride
+}
+
+// Size is the number of bytes covered by the index.
+func (x *Index) Size() int64 {
+	return x.size
+}
+
+// OffsetOfLine returns the start of line n. Line Lines() starts at Size().
+func (x *Index) OffsetOfLine(n int) (int64, error) {
+	if n < 0 || n > x.lines {
+		return 0, ErrLineOutOfRange
+	}
+	if n == x.lines {
+		return x.size, nil
+	}
+	line, err := x.line(n)
+	if err != nil {
+		return 0, err
+	}
+	return line.Start, nil
+}
+
+// LineOfOffset returns the number of the line containing offset. Size() is in line Lines() when the
+// indexed data ends with a delimiter, like OffsetOfLine.
+func (x *Index) LineOfOffset(offset int64) (int, error) {
+	if offset < 0 || offset > x.size {
+		return 0, ErrInvalidPosition
+	}
+	block := sort.Search(len(x.offsets), func(i int) bool {
+		return x.offsets[i] > offset
+	}) - 1
+	if block < 0 {
+		return 0, nil
+	}
+	forward, err := newForward(x.reader, x.offsets[block], x.opts)
+	if err != nil {
+		return 0, err
+	}
+	defer forward.Close()
+	line, terminated := block*x.stride, false
+	err = forEachLine(forward, func(number int, info LineInfo, _ []byte) bool {
+		if info.Start > offset {
+			return false
+		}
+		line, terminated = block*x.stride+number, info.Terminated
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if offset == x.size && terminated && line == x.lines-1 {
+		return x.lines, nil
+	}
+	return line, nil
+}
+
+// ForwardAt returns a forward scanner whose first line is line n.
+func (x *Index) ForwardAt(n int) (*forward, error) {
+	offset, err := x.OffsetOfLine(n)
+	if err != nil {
+		return nil, err
+	}
+	return newForward(x.reader, offset, x.opts)
+}
+
+// BackwardAt returns a backward scanner whose first line is line n.
+func (x *Index) BackwardAt(n int) (*backward, error) {
+	if n < 0 || n >= x.lines {
+		return nil, ErrLineOutOfRange
+	}
+	line, err := x.line(n)
+	if err != nil {
+		return nil, err
+	}
+	return newBackward(x.reader, lineEnd(line), x.opts)
+}
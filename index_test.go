@@ -0,0 +1,275 @@
+package linescanner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func indexData(lines int) string {
+	return formatLines(lines, "line%d\n", nil)
+}
+
+func TestIndex_NewIndex(t *testing.T) {
+	// given
+	data := "ab\ncd\r\nef\ngh\nij"
+
+	// when
+	index, err := NewIndex(strings.NewReader(data), WithIndexStride(2))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, index.Stride(), 2)
+	assert.Equal(t, index.Lines(), 5)
+	assert.Equal(t, index.Size(), int64(len(data)))
+	assert.Equal(t, index.offsets, []int64{0, 7, 13})
+}
+
+func TestIndex_NewIndex_TrailingDelimiter(t *testing.T) {
+	// given
+	data := "ab\ncd\n"
+
+	// when
+	index, err := NewIndex(strings.NewReader(data))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, index.Stride(), defaultIndexStride)
+	assert.Equal(t, index.Lines(), 2)
+	assert.Equal(t, index.Size(), int64(6))
+	assert.Equal(t, index.offsets, []int64{0})
+}
+
+func TestIndex_NewIndex_Empty(t *testing.T) {
+	// when
+	index, err := NewIndex(strings.NewReader(""))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, index.Lines(), 0)
+	assert.Equal(t, index.Size(), int64(0))
+	assert.Empty(t, index.offsets)
+
+	// when
+	offset, err := index.OffsetOfLine(0)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(0))
+
+	// when
+	line, err := index.LineOfOffset(0)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, 0)
+}
+
+func TestIndex_NewIndex_Errors(t *testing.T) {
+	// when
+	_, err := NewIndex(nil)
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = NewIndex(strings.NewReader("ab"), WithIndexStride(0))
+
+	// then
+	assert.Equal(t, err, ErrInvalidIndexStride)
+
+	// when
+	_, err = NewIndex(strings.NewReader("abcdef"), WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestIndex_OffsetOfLine(t *testing.T) {
+	// given
+	data := indexData(10)
+	index, err := NewIndex(strings.NewReader(data), WithIndexStride(3))
+	assert.Nil(t, err)
+
+	for n := 0; n < 10; n++ {
+		// when
+		offset, err := index.OffsetOfLine(n)
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, offset, int64(strings.Index(data, fmt.Sprintf("line%d\n", n))))
+	}
+
+	// when
+	offset, err := index.OffsetOfLine(10)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(len(data)))
+
+	// when
+	_, err = index.OffsetOfLine(11)
+
+	// then
+	assert.Equal(t, err, ErrLineOutOfRange)
+
+	// when
+	_, err = index.OffsetOfLine(-1)
+
+	// then
+	assert.Equal(t, err, ErrLineOutOfRange)
+}
+
+func TestIndex_LineOfOffset(t *testing.T) {
+	// given
+	data := indexData(10)
+	index, err := NewIndex(strings.NewReader(data), WithIndexStride(3))
+	assert.Nil(t, err)
+
+	for offset := 0; offset < len(data); offset++ {
+		// when
+		line, err := index.LineOfOffset(int64(offset))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, line, strings.Count(data[:offset], "\n"), "%d", offset)
+	}
+
+	// when
+	line, err := index.LineOfOffset(int64(len(data)))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, 10)
+
+	// when
+	_, err = index.LineOfOffset(int64(len(data) + 1))
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+}
+
+func TestIndex_LineOfOffset_Size(t *testing.T) {
+	tests := []struct {
+		data string
+		line int
+	}{
+		{"a\nb\n", 2},
+		{"a\r\nb\r\n", 2},
+		{"a\nb", 1},
+		{"a\n\n", 2},
+		{"\n", 1},
+		{"", 0},
+	}
+
+	for _, test := range tests {
+		// given
+		index, err := NewIndex(strings.NewReader(test.data), WithIndexStride(1))
+		assert.Nil(t, err)
+		offset, err := index.OffsetOfLine(index.Lines())
+		assert.Nil(t, err)
+		assert.Equal(t, offset, index.Size())
+
+		// when
+		line, err := index.LineOfOffset(index.Size())
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, line, test.line, test.data)
+	}
+}
+
+func TestIndex_LongLine(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\r\ncd\nef"
+	opts := []Option{WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment), WithIndexStride(2)}
+	index, err := NewIndex(strings.NewReader(data), opts...)
+	assert.Nil(t, err)
+
+	// then
+	assert.Equal(t, index.Lines(), 4)
+	assert.Equal(t, index.offsets, []int64{0, 15})
+
+	// when
+	offset, err := index.OffsetOfLine(2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(15))
+
+	// when
+	line, err := index.LineOfOffset(10)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, 1)
+
+	// when
+	backward, err := index.BackwardAt(1)
+	assert.Nil(t, err)
+	var lines []string
+	for backward.Scan() {
+		lines = append(lines, backward.Text())
+	}
+
+	// then
+	assert.Equal(t, lines, []string{"ij", "fgh", "cde", "ab", "ab"})
+}
+
+func TestIndex_ForwardAt(t *testing.T) {
+	// given
+	data := indexData(10)
+	index, err := NewIndex(strings.NewReader(data), WithIndexStride(4))
+	assert.Nil(t, err)
+
+	// when
+	forward, err := index.ForwardAt(6)
+	assert.Nil(t, err)
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "line6")
+
+	// when
+	forward, err = index.ForwardAt(10)
+	assert.Nil(t, err)
+	line, err = forward.Line()
+
+	// then
+	assert.Equal(t, err, io.EOF)
+	assert.Empty(t, line)
+
+	// when
+	_, err = index.ForwardAt(11)
+
+	// then
+	assert.Equal(t, err, ErrLineOutOfRange)
+}
+
+func TestIndex_BackwardAt(t *testing.T) {
+	// given
+	data := "ab\ncd\r\nef\ngh"
+	index, err := NewIndex(strings.NewReader(data), WithIndexStride(3))
+	assert.Nil(t, err)
+
+	for n, want := range []string{"ab", "cd", "ef", "gh"} {
+		// when
+		backward, err := index.BackwardAt(n)
+		assert.Nil(t, err)
+		line, err := backward.Line()
+
+		// then
+		assert.Equal(t, line, want)
+		assert.Equal(t, backward.info.Start, int64(strings.Index(data, want)))
+	}
+
+	// when
+	_, err = index.BackwardAt(4)
+
+	// then
+	assert.Equal(t, err, ErrLineOutOfRange)
+}
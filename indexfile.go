@@ -0,0 +1,215 @@
+package linescanner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+const (
+	indexMagic        = "LSIX"
+	indexVersion      = 1
+	fingerprintWindow = 4096
+
+	maxIndexDelimiterSize = 1 << 10
+)
+
+// fingerprint returns the modification time of reader, when it has one, and a checksum of
+// the first and last bytes of its first size bytes.
+func fingerprint(reader io.ReaderAt, size int64) (int64, uint32, error) {
+	var modTime int64
+	if r, ok := reader.(interface{ Stat() (os.FileInfo, error) }); ok {
+		if info, err := r.Stat(); err == nil {
+			modTime = info.ModTime().UnixNano()
+		}
+	}
+	head := make([]byte, minInt64(size, fingerprintWindow))
+	if err := readFullAt(reader, head, 0); err != nil {
+		return 0, 0, err
+	}
+	tail := make([]byte, minInt64(size-int64(len(head)), fingerprintWindow))
+	if err := readFullAt(reader, tail, size-int64(len(tail))); err != nil {
+		return 0, 0, err
+	}
+	checksum := crc32.Update(crc32.ChecksumIEEE(head), crc32.IEEETable, tail)
+	return modTime, checksum, nil
+}
+
+// WriteTo writes the index in a versioned binary format: a header with the delimiter, the
+// long line policy, the stride, the indexed size and the source fingerprint, followed by
+// the line count and the delta encoded offsets.
+func (x *Index) WriteTo(w io.Writer) (int64, error) {
+	buf := []byte(indexMagic)
+	buf = binary.AppendUvarint(buf, indexVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(x.delimiter)))
+	buf = append(buf, x.delimiter...)
+	buf = binary.AppendUvarint(buf, uint64(x.longLinePolicy))
+	buf = binary.AppendUvarint(buf, uint64(x.stride))
+	buf = binary.AppendUvarint(buf, uint64(x.size))
+	buf = binary.AppendVarint(buf, x.modTime)
+	buf = binary.LittleEndian.AppendUint32(buf, x.checksum)
+	buf = binary.AppendUvarint(buf, uint64(x.lines))
+	buf = binary.AppendUvarint(buf, uint64(len(x.offsets)))
+	prev := int64(0)
+	for _, offset := range x.offsets {
+		buf = binary.AppendUvarint(buf, uint64(offset-prev))
+		prev = offset
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// LoadIndex reads an index written by WriteTo for reader. The delimiter and long line policy
+// in opts must match the ones the index was built with.
+func LoadIndex(r io.Reader, reader io.ReaderAt, opts ...Option) (*Index, error) {
+	if _, err := newForward(reader, 0, opts); err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+	if err := o.validateIndex(); err != nil {
+		return nil, err
+	}
+	index, err := readIndex(bufio.NewReader(r))
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrInvalidIndexFormat
+		}
+		return nil, err
+	}
+	if !bytes.Equal(index.delimiter, o.delimiter) || index.longLinePolicy != o.longLinePolicy {
+		return nil, ErrIndexMismatch
+	}
+	index.reader = reader
+	index.opts = opts
+	return index, nil
+}
+
+func readIndex(r *bufio.Reader) (*Index, error) {
+	magic := make([]byte, len(indexMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != indexMagic {
+		return nil, ErrInvalidIndexFormat
+	}
+	version, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if version != indexVersion {
+		return nil, ErrIndexVersion
+	}
+	delimiterSize, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if delimiterSize == 0 || delimiterSize > maxIndexDelimiterSize {
+		return nil, ErrInvalidIndexFormat
+	}
+	index := &Index{delimiter: make([]byte, delimiterSize)}
+	if _, err := io.ReadFull(r, index.delimiter); err != nil {
+		return nil, err
+	}
+	policy, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	stride, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if index.modTime, err = binary.ReadVarint(r); err != nil {
+		return nil, err
+	}
+	var checksum [4]byte
+	if _, err := io.ReadFull(r, checksum[:]); err != nil {
+		return nil, err
+	}
+	lines, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	index.longLinePolicy = LongLinePolicy(policy)
+	index.stride = int(stride)
+	index.size = int64(size)
+	index.checksum = binary.LittleEndian.Uint32(checksum[:])
+	index.lines = int(lines)
+	if index.stride <= 0 || index.size < 0 || index.lines < 0 {
+		return nil, ErrInvalidIndexFormat
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if count != uint64((index.lines+index.stride-1)/index.stride) {
+		return nil, ErrInvalidIndexFormat
+	}
+	offset := int64(0)
+	for i := uint64(0); i < count; i++ {
+		delta, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		offset += int64(delta)
+		if offset < 0 || offset > index.size || i > 0 && delta == 0 {
+			return nil, ErrInvalidIndexFormat
+		}
+		index.offsets = append(index.offsets, offset)
+	}
+	return index, nil
+}
+
+// Stale reports whether the indexed bytes of the reader have changed since the index was built.
+// Bytes appended after them do not make the index stale; Extend indexes them.
+func (x *Index) Stale() (bool, error) {
+	modTime, checksum, err := fingerprint(x.reader, x.size)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return true, nil
+		}
+		return false, err
+	}
+	if checksum != x.checksum {
+		return true, nil
+	}
+	if size, ok := readerSize(x.reader); ok && size == x.size && modTime != x.modTime {
+		return true, nil
+	}
+	return false, nil
+}
+
+// Extend indexes lines appended to the reader since the index was built or last extended.
+func (x *Index) Extend() error {
+	stale, err := x.Stale()
+	if err != nil {
+		return err
+	}
+	if stale {
+		return ErrStaleIndex
+	}
+	start, firstLine := int64(0), 0
+	if x.lines > 0 {
+		firstLine = x.lines - 1
+		if start, err = x.OffsetOfLine(firstLine); err != nil {
+			return err
+		}
+	}
+	forward, err := newForward(x.reader, start, x.opts)
+	if err != nil {
+		return err
+	}
+	defer forward.Close()
+	if err := x.scan(forward, firstLine); err != nil {
+		return err
+	}
+	x.modTime, x.checksum, err = fingerprint(x.reader, x.size)
+	return err
+}
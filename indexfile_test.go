@@ -0,0 +1,206 @@
+package linescanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openIndexSource(t *testing.T, data string) (string, *os.File) {
+	path := filepath.Join(t.TempDir(), "log")
+	assert.Nil(t, os.WriteFile(path, []byte(data), 0o644))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		file.Close()
+	})
+	return path, file
+}
+
+func TestIndex_WriteTo_LoadIndex(t *testing.T) {
+	// given
+	_, file := openIndexSource(t, indexData(100))
+	index, err := NewIndex(file, WithIndexStride(10))
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+
+	// when
+	n, err := index.WriteTo(&buffer)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, n, int64(buffer.Len()))
+	assert.Less(t, buffer.Len(), 48)
+
+	// when
+	loaded, err := LoadIndex(&buffer, file, WithIndexStride(3))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, loaded.Stride(), 10)
+	assert.Equal(t, loaded.Lines(), index.Lines())
+	assert.Equal(t, loaded.Size(), index.Size())
+	assert.Equal(t, loaded.offsets, index.offsets)
+	assert.Equal(t, loaded.delimiter, index.delimiter)
+	assert.Equal(t, loaded.modTime, index.modTime)
+	assert.Equal(t, loaded.checksum, index.checksum)
+
+	// when
+	offset, err := loaded.OffsetOfLine(42)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(strings.Index(indexData(100), "line42\n")))
+
+	// when
+	stale, err := loaded.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.False(t, stale)
+}
+
+func TestIndex_LoadIndex_Errors(t *testing.T) {
+	// given
+	reader := strings.NewReader("ab\ncd\n")
+	index, err := NewIndex(reader, WithDelimiter([]byte("\r\n")))
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+	_, err = index.WriteTo(&buffer)
+	assert.Nil(t, err)
+	data := buffer.Bytes()
+
+	tests := []struct {
+		data []byte
+		opts []Option
+		err  error
+	}{
+		{data, nil, ErrIndexMismatch},
+		{data, []Option{WithDelimiter([]byte("\r\n")), WithLongLinePolicy(LongLineSkip)}, ErrIndexMismatch},
+		{data[:len(data)-1], []Option{WithDelimiter([]byte("\r\n"))}, ErrInvalidIndexFormat},
+		{data[:3], nil, ErrInvalidIndexFormat},
+		{[]byte("XXXX"), nil, ErrInvalidIndexFormat},
+		{append([]byte(indexMagic), 2), nil, ErrIndexVersion},
+		{nil, []Option{WithIndexStride(0)}, ErrInvalidIndexStride},
+	}
+
+	for _, test := range tests {
+		// when
+		loaded, err := LoadIndex(bytes.NewReader(test.data), reader, test.opts...)
+
+		// then
+		assert.Nil(t, loaded)
+		assert.Equal(t, err, test.err)
+	}
+
+	// when
+	loaded, err := LoadIndex(bytes.NewReader(data), reader, WithDelimiter([]byte("\r\n")))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, loaded.Lines(), 1)
+}
+
+func TestIndex_Stale(t *testing.T) {
+	// given
+	path, file := openIndexSource(t, "ab\ncd\n")
+	index, err := NewIndex(file)
+	assert.Nil(t, err)
+
+	// when
+	assert.Nil(t, os.WriteFile(path, []byte("ab\nce\n"), 0o644))
+	stale, err := index.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.True(t, stale)
+
+	// when
+	assert.Nil(t, os.WriteFile(path, []byte("ab\n"), 0o644))
+	stale, err = index.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.True(t, stale)
+
+	// when
+	assert.Equal(t, index.Extend(), ErrStaleIndex)
+}
+
+func TestIndex_Stale_ModTime(t *testing.T) {
+	// given
+	path, file := openIndexSource(t, "ab\ncd\n")
+	index, err := NewIndex(file)
+	assert.Nil(t, err)
+
+	// when
+	modTime := time.Unix(0, index.modTime).Add(time.Hour)
+	assert.Nil(t, os.Chtimes(path, modTime, modTime))
+	stale, err := index.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.True(t, stale)
+}
+
+func TestIndex_Extend(t *testing.T) {
+	// given
+	path, file := openIndexSource(t, "l0\nl1\nl2\nl")
+	index, err := NewIndex(file, WithIndexStride(2))
+	assert.Nil(t, err)
+	assert.Equal(t, index.Lines(), 4)
+
+	// when
+	appendFile(t, path, "3\nl4\nl5\n")
+	stale, err := index.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.False(t, stale)
+
+	// when
+	err = index.Extend()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, index.Lines(), 6)
+	assert.Equal(t, index.Size(), int64(18))
+	assert.Equal(t, index.offsets, []int64{0, 6, 12})
+
+	// when
+	stale, err = index.Stale()
+
+	// then
+	assert.Nil(t, err)
+	assert.False(t, stale)
+
+	// when
+	forward, err := index.ForwardAt(3)
+	assert.Nil(t, err)
+	line, err := forward.Line()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "l3")
+}
+
+func TestIndex_Extend_Empty(t *testing.T) {
+	// given
+	path, file := openIndexSource(t, "")
+	index, err := NewIndex(file)
+	assert.Nil(t, err)
+
+	// when
+	appendFile(t, path, "ab\ncd")
+	err = index.Extend()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, index.Lines(), 2)
+	assert.Equal(t, index.offsets, []int64{0})
+}
@@ -2,25 +2,115 @@ package linescanner
 
 import (
 	"errors"
+	"time"
 )
 
 var (
-	ErrReadFailure          = errors.New("read failure")
-	ErrNilReader            = errors.New("reader is nil")
-	ErrInvalidPosition      = errors.New("invalid position")
-	ErrInvalidMaxChunkSize  = errors.New("max chunk size is invalid")
-	ErrInvalidMaxBufferSize = errors.New("max buffer size is invalid")
-	ErrGreaterBufferSize    = errors.New("buffer size must be greater than chunk size")
-	ErrBufferOverflow       = errors.New("buffer is overflow")
+	ErrReadFailure           = errors.New("read failure")
+	ErrNilReader             = errors.New("reader is nil")
+	ErrInvalidPosition       = errors.New("invalid position")
+	ErrInvalidMaxChunkSize   = errors.New("max chunk size is invalid")
+	ErrInvalidMaxBufferSize  = errors.New("max buffer size is invalid")
+	ErrGreaterBufferSize     = errors.New("buffer size must be greater than chunk size")
+	ErrBufferOverflow        = errors.New("buffer is overflow")
+	ErrEmptyDelimiter        = errors.New("delimiter is empty")
+	ErrNilSplitFunc          = errors.New("split func is nil")
+	ErrSplitFuncUnsupported  = errors.New("split func is not supported")
+	ErrInvalidLongLinePolicy = errors.New("long line policy is invalid")
+	ErrInvalidPollInterval   = errors.New("poll interval is invalid")
+	ErrInvalidRotationPolicy = errors.New("rotation policy is invalid")
+	ErrInvalidLineCount      = errors.New("line count is invalid")
+	ErrInvalidLineRange      = errors.New("line range is invalid")
+	ErrLineOutOfRange        = errors.New("line is out of range")
+	ErrInvalidIndexStride    = errors.New("index stride is invalid")
+	ErrInvalidIndexFormat    = errors.New("index format is invalid")
+	ErrIndexVersion          = errors.New("index version is unsupported")
+	ErrIndexMismatch         = errors.New("index does not match options")
+	ErrStaleIndex            = errors.New("index is stale")
+	ErrNoTimestamp           = errors.New("no timestamp near line")
+	ErrInvalidShardCount     = errors.New("shard count is invalid")
+	ErrMmapUnsupported       = errors.New("mmap is not supported")
+	ErrInvalidPrefetch       = errors.New("prefetch is invalid")
+	ErrScannerClosed         = errors.New("scanner is closed")
+	ErrInvalidMinChunkSize   = errors.New("min chunk size is invalid")
 )
 
+var defaultDelimiter = []byte{'\n'}
+
 const (
 	defaultMaxChunkSize  = 4096
 	defaultMaxBufferSize = 1 << 20
 	endPosition          = -1
+	defaultPollInterval  = time.Second
+	defaultIndexStride   = 1000
+)
+
+type Direction int
+
+const (
+	Forward Direction = iota
+	Backward
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Forward:
+		return "forward"
+	case Backward:
+		return "backward"
+	}
+	return "unknown"
+}
+
+type LongLinePolicy int
+
+const (
+	LongLineError LongLinePolicy = iota
+	LongLineTruncate
+	LongLineSkip
+	LongLineFragment
 )
 
+type RotationPolicy int
+
+const (
+	RotationReopen RotationPolicy = iota
+	RotationDrain
+)
+
+// LineInfo locates a returned line in the reader. Start and End exclude the delimiter and a removed
+// carriage return; for a truncated line they span the whole line, for a fragment only the fragment.
+type LineInfo struct {
+	Start          int64
+	End            int64
+	CarriageReturn bool
+	Terminated     bool
+	Truncated      bool
+	Continued      bool
+}
+
+// Stats counts the reads of a scanner. ChunkSize is the size of the last read and MaxChunkSize of
+// the largest.
+type Stats struct {
+	Reads        int
+	BytesRead    int64
+	ChunkSize    int
+	MaxChunkSize int
+}
+
+func (s *Stats) add(chunkSize int, n int) {
+	s.Reads++
+	s.BytesRead += int64(n)
+	s.ChunkSize = chunkSize
+	s.MaxChunkSize = maxInt(s.MaxChunkSize, chunkSize)
+}
+
 type LineScanner interface {
 	Line() (line string, err error)
 	Position() int
 }
+
+type ByteLineScanner interface {
+	LineScanner
+	LineBytes() (line []byte, err error)
+}
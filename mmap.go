@@ -0,0 +1,258 @@
+package linescanner
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"math"
+	"os"
+)
+
+// Mmap is a file mapped into memory. Its scanners return lines as slices of the mapping without
+// copying; when the file cannot be mapped they fall back to the io.ReaderAt scanners.
+type Mmap struct {
+	file *os.File
+	data []byte
+	size int64
+}
+
+func OpenMmap(path string) (*Mmap, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	m := &Mmap{file: file, size: info.Size()}
+	switch {
+	case m.size == 0:
+		m.data = []byte{}
+	case m.size <= math.MaxInt:
+		if data, err := mmapFile(file, int(m.size)); err == nil {
+			m.data = data
+		}
+	}
+	return m, nil
+}
+
+// Mapped reports whether the file is mapped or read through ReadAt.
+func (m *Mmap) Mapped() bool {
+	return m.data != nil
+}
+
+func (m *Mmap) Size() int64 {
+	return m.size
+}
+
+func (m *Mmap) ReadAt(p []byte, off int64) (int, error) {
+	if m.data == nil {
+		return m.file.ReadAt(p, off)
+	}
+	if off < 0 {
+		return 0, ErrInvalidPosition
+	}
+	if off >= m.size {
+		return 0, io.EOF
+	}
+	n := copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (m *Mmap) Close() error {
+	if len(m.data) > 0 {
+		if err := munmap(m.data); err != nil {
+			return err
+		}
+	}
+	m.data = nil
+	return m.file.Close()
+}
+
+// NewForward returns a forward scanner starting at position. Lines of a mapped file are never
+// buffered, so the chunk, buffer, long line, prefetch and buffer pool options do not apply to it.
+func (m *Mmap) NewForward(position int64, opts ...Option) (ByteLineScanner, error) {
+	if m.data == nil {
+		return TryNewForward64(m, position, opts...)
+	}
+	return newMmapScanner(m, Forward, position, opts)
+}
+
+// NewBackward returns a backward scanner ending at position, like NewForward.
+func (m *Mmap) NewBackward(position int64, opts ...Option) (ByteLineScanner, error) {
+	if m.data == nil {
+		return TryNewBackward64(m, position, opts...)
+	}
+	return newMmapScanner(m, Backward, position, opts)
+}
+
+type mmapScanner struct {
+	data      []byte
+	direction Direction
+
+	delimiter          []byte
+	keepCarriageReturn bool
+
+	position       int64
+	lineTerminated bool
+	// leadingLine is set once a backward scan passes the delimiter at offset 0, which leaves the empty first line
+	leadingLine bool
+
+	line   []byte
+	info   LineInfo
+	noLine bool
+}
+
+func newMmapScanner(m *Mmap, direction Direction, position int64, opts []Option) (*mmapScanner, error) {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if err := o.validateReads(); err != nil {
+		return nil, err
+	}
+	if o.split != nil {
+		return nil, ErrSplitFuncUnsupported
+	}
+	if err := validatePosition(m, position); err != nil {
+		return nil, err
+	}
+	s := &mmapScanner{
+		data:               m.data,
+		direction:          direction,
+		delimiter:          o.delimiter,
+		keepCarriageReturn: !o.trimCarriageReturn(),
+		position:           position,
+	}
+	if direction == Backward && position >= 0 {
+		s.lineTerminated = bytes.HasPrefix(m.data[position:], o.delimiter)
+	}
+	return s, nil
+}
+
+func (s *mmapScanner) newLine(start int64, end int64, terminated bool) []byte {
+	line := s.data[start:end]
+	s.info = LineInfo{
+		Start:      start,
+		End:        end,
+		Terminated: terminated,
+	}
+	if !s.keepCarriageReturn {
+		if trimmed := trimCarriageReturn(line); len(trimmed) < len(line) {
+			line = trimmed
+			s.info.End--
+			s.info.CarriageReturn = true
+		}
+	}
+	return line
+}
+
+func (s *mmapScanner) forwardLine() ([]byte, error) {
+	if s.position < 0 {
+		return nil, io.EOF
+	}
+	start := s.position
+	lineSize := bytes.Index(s.data[start:], s.delimiter)
+	if lineSize < 0 {
+		s.position = endPosition
+		return s.newLine(start, int64(len(s.data)), false), io.EOF
+	}
+	s.position += int64(lineSize + len(s.delimiter))
+	return s.newLine(start, start+int64(lineSize), true), nil
+}
+
+func (s *mmapScanner) backwardLine() ([]byte, error) {
+	if s.leadingLine {
+		s.leadingLine = false
+		return s.newLine(0, 0, true), io.EOF
+	}
+	if s.position <= 0 {
+		s.noLine = true
+		return nil, io.EOF
+	}
+	end := s.position
+	terminated := s.lineTerminated
+	s.lineTerminated = true
+	delimiterStartPos := bytes.LastIndex(s.data[:end], s.delimiter)
+	if delimiterStartPos < 0 {
+		s.position = 0
+		return s.newLine(0, end, terminated), io.EOF
+	}
+	s.position = int64(delimiterStartPos)
+	s.leadingLine = delimiterStartPos == 0
+	return s.newLine(int64(delimiterStartPos+len(s.delimiter)), end, terminated), nil
+}
+
+func (s *mmapScanner) Line() (string, error) {
+	line, err := s.LineBytes()
+	return string(line), err
+}
+
+func (s *mmapScanner) LineBytes() ([]byte, error) {
+	s.info = LineInfo{}
+	s.noLine = false
+	if s.direction == Forward {
+		return s.forwardLine()
+	}
+	return s.backwardLine()
+}
+
+func (s *mmapScanner) LineWithRange() (string, LineInfo, error) {
+	line, err := s.LineBytes()
+	return string(line), s.info, err
+}
+
+func (s *mmapScanner) Position() int {
+	return int(s.Position64())
+}
+
+func (s *mmapScanner) Position64() int64 {
+	if s.direction == Backward && s.position <= 0 {
+		return endPosition
+	}
+	return s.position
+}
+
+func (s *mmapScanner) Scan() bool {
+	if s.direction == Forward && s.position < 0 || s.direction == Backward && s.position <= 0 && !s.leadingLine {
+		s.line = nil
+		return false
+	}
+	line, err := s.LineBytes()
+	if err == nil && trailingLine(line, s.info) {
+		line, err = s.LineBytes()
+	}
+	if err == io.EOF && (s.noLine || trailingLine(line, s.info)) {
+		s.line = nil
+		return false
+	}
+	s.line = line
+	return true
+}
+
+func (s *mmapScanner) Text() string {
+	return string(s.line)
+}
+
+func (s *mmapScanner) Bytes() []byte {
+	return s.line
+}
+
+func (s *mmapScanner) Err() error {
+	return nil
+}
+
+func (s *mmapScanner) Lines() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for s.Scan() {
+			if !yield(s.Text()) {
+				return
+			}
+		}
+	}
+}
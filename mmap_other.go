@@ -0,0 +1,15 @@
+//go:build !linux
+
+package linescanner
+
+import (
+	"os"
+)
+
+func mmapFile(file *os.File, size int) ([]byte, error) {
+	return nil, ErrMmapUnsupported
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,220 @@
+package linescanner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scannedLine struct {
+	line string
+	info LineInfo
+	err  error
+}
+
+func openMmap(t *testing.T, data string) *Mmap {
+	path := filepath.Join(t.TempDir(), "log")
+	assert.Nil(t, os.WriteFile(path, []byte(data), 0o644))
+	m, err := OpenMmap(path)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		m.Close()
+	})
+	return m
+}
+
+func scanRanges(scanner interface {
+	LineWithRange() (string, LineInfo, error)
+}) []scannedLine {
+	var lines []scannedLine
+	for {
+		line, info, err := scanner.LineWithRange()
+		lines = append(lines, scannedLine{line, info, err})
+		if err != nil {
+			return lines
+		}
+	}
+}
+
+func TestMmap_Scanners(t *testing.T) {
+	inputs := []string{
+		"",
+		"\n",
+		"a",
+		"a\n",
+		"\n\r\n\n",
+		"\na\n",
+		"aaa\nbb\r\nc\n\nddddd",
+		"aaa\nbb\r\nc\n\nddddd\r\n",
+	}
+	for _, data := range inputs {
+		// given
+		m := openMmap(t, data)
+		if runtime.GOOS == "linux" {
+			assert.True(t, m.Mapped())
+		}
+
+		for position := int64(0); position <= int64(len(data)); position++ {
+			// when
+			mmapForward, err := m.NewForward(position)
+			assert.Nil(t, err)
+			forward, err := TryNewForward64(strings.NewReader(data), position)
+			assert.Nil(t, err)
+
+			// then
+			assert.Equal(t, scanRanges(mmapForward.(*mmapScanner)), scanRanges(forward), "%q %d", data, position)
+
+			// when
+			mmapBackward, err := m.NewBackward(position)
+			assert.Nil(t, err)
+			backward, err := TryNewBackward64(strings.NewReader(data), position)
+			assert.Nil(t, err)
+
+			// then
+			assert.Equal(t, scanRanges(mmapBackward.(*mmapScanner)), scanRanges(backward), "%q %d", data, position)
+		}
+	}
+}
+
+func TestMmap_Scan(t *testing.T) {
+	tests := []struct {
+		data     string
+		forward  []string
+		backward []string
+	}{
+		{"a\r\nb\n\nc\n", []string{"a", "b", "", "c"}, []string{"c", "", "b", "a"}},
+		{"\na\n", []string{"", "a"}, []string{"a", ""}},
+		{"\n", []string{""}, []string{""}},
+	}
+
+	for _, test := range tests {
+		// given
+		m := openMmap(t, test.data)
+
+		// when
+		forward, err := m.NewForward(0)
+		assert.Nil(t, err)
+		backward, err := m.NewBackward(int64(len(test.data)))
+		assert.Nil(t, err)
+
+		// then
+		var lines []string
+		for line := range forward.(*mmapScanner).Lines() {
+			lines = append(lines, line)
+		}
+		assert.Equal(t, lines, test.forward, test.data)
+		lines = nil
+		for line := range backward.(*mmapScanner).Lines() {
+			lines = append(lines, line)
+		}
+		assert.Equal(t, lines, test.backward, test.data)
+	}
+}
+
+func TestMmap_ZeroCopy(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("mmap is only used on linux")
+	}
+
+	// given
+	data := "first\nsecond\n"
+	m := openMmap(t, data)
+	forward, err := m.NewForward(0)
+	assert.Nil(t, err)
+
+	// when
+	_, err = forward.LineBytes()
+	assert.Nil(t, err)
+	line, err := forward.LineBytes()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, string(line), "second")
+	assert.Equal(t, unsafe.SliceData(line), &m.data[6])
+}
+
+func TestMmap_Fallback(t *testing.T) {
+	// given
+	data := "a\nbb\nccc"
+	path := filepath.Join(t.TempDir(), "log")
+	assert.Nil(t, os.WriteFile(path, []byte(data), 0o644))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	m := &Mmap{file: file, size: int64(len(data))}
+	defer m.Close()
+
+	// when
+	forwardScanner, err := m.NewForward(2)
+	assert.Nil(t, err)
+	backwardScanner, err := m.NewBackward(5)
+	assert.Nil(t, err)
+
+	// then
+	assert.False(t, m.Mapped())
+	assert.IsType(t, forwardScanner, &forward{})
+	line, err := forwardScanner.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "bb")
+	assert.IsType(t, backwardScanner, &backward{})
+	line, err = backwardScanner.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "")
+}
+
+func TestMmap_ReadAt(t *testing.T) {
+	// given
+	m := openMmap(t, "abcdef")
+	buffer := make([]byte, 4)
+
+	// when
+	n, err := m.ReadAt(buffer, 4)
+
+	// then
+	assert.Equal(t, n, 2)
+	assert.Equal(t, err, io.EOF)
+	assert.Equal(t, string(buffer[:n]), "ef")
+
+	// when
+	n, err = m.ReadAt(buffer, 1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, string(buffer[:n]), "bcde")
+	assert.Equal(t, m.Size(), int64(6))
+}
+
+func TestMmap_Errors(t *testing.T) {
+	// when
+	_, err := OpenMmap(filepath.Join(t.TempDir(), "missing"))
+
+	// then
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// given
+	m := openMmap(t, "a\nb")
+
+	// when
+	_, err = m.NewForward(4)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = m.NewBackward(0, WithSplitFunc(bufio.ScanWords))
+
+	// then
+	assert.Equal(t, err, ErrSplitFuncUnsupported)
+
+	// when
+	_, err = m.NewForward(0, WithDelimiter(nil))
+
+	// then
+	assert.Equal(t, err, ErrEmptyDelimiter)
+}
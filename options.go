@@ -0,0 +1,184 @@
+package linescanner
+
+import (
+	"bufio"
+	"bytes"
+	"time"
+)
+
+type Option func(*options)
+
+type options struct {
+	maxChunkSize       int
+	maxBufferSize      int
+	delimiter          []byte
+	keepCarriageReturn bool
+	split              bufio.SplitFunc
+	longLinePolicy     LongLinePolicy
+	pollInterval       time.Duration
+	rotationPolicy     RotationPolicy
+	onRotate           func(RotationEvent)
+	indexStride        int
+	prefetch           int
+	adaptiveChunkSize  bool
+	minChunkSize       int
+	bufferPool         BufferPool
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		maxChunkSize:  defaultMaxChunkSize,
+		maxBufferSize: defaultMaxBufferSize,
+		delimiter:     defaultDelimiter,
+		pollInterval:  defaultPollInterval,
+		indexStride:   defaultIndexStride,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// validate checks the options shared by scanners and cursors. The options that only apply to the reads
+// of forward and backward scanners, to followers or to indexes are checked by validateReads,
+// validateFollow and validateIndex, so that each constructor ignores the options of the others.
+func (o *options) validate() error {
+	if o.maxChunkSize <= 0 {
+		return ErrInvalidMaxChunkSize
+	}
+	if o.maxBufferSize <= 0 {
+		return ErrInvalidMaxBufferSize
+	}
+	if o.maxChunkSize > o.maxBufferSize {
+		return ErrGreaterBufferSize
+	}
+	if len(o.delimiter) == 0 {
+		return ErrEmptyDelimiter
+	}
+	if o.longLinePolicy < LongLineError || o.longLinePolicy > LongLineFragment {
+		return ErrInvalidLongLinePolicy
+	}
+	if o.split != nil && o.longLinePolicy != LongLineError {
+		return ErrSplitFuncUnsupported
+	}
+	return nil
+}
+
+func (o *options) validateReads() error {
+	if o.prefetch < 0 {
+		return ErrInvalidPrefetch
+	}
+	if o.adaptiveChunkSize && (o.minChunkSize <= 0 || o.minChunkSize > o.maxChunkSize) {
+		return ErrInvalidMinChunkSize
+	}
+	return nil
+}
+
+func (o *options) validateFollow() error {
+	if o.pollInterval <= 0 {
+		return ErrInvalidPollInterval
+	}
+	if o.rotationPolicy < RotationReopen || o.rotationPolicy > RotationDrain {
+		return ErrInvalidRotationPolicy
+	}
+	return nil
+}
+
+func (o *options) validateIndex() error {
+	if o.indexStride <= 0 {
+		return ErrInvalidIndexStride
+	}
+	return nil
+}
+
+func (o *options) trimCarriageReturn() bool {
+	return !o.keepCarriageReturn && bytes.Equal(o.delimiter, defaultDelimiter)
+}
+
+func WithChunkSize(size int) Option {
+	return func(o *options) {
+		o.maxChunkSize = size
+	}
+}
+
+func WithMaxBufferSize(size int) Option {
+	return func(o *options) {
+		o.maxBufferSize = size
+	}
+}
+
+func WithDelimiter(delimiter []byte) Option {
+	return func(o *options) {
+		o.delimiter = append([]byte(nil), delimiter...)
+	}
+}
+
+func WithKeepCarriageReturn() Option {
+	return func(o *options) {
+		o.keepCarriageReturn = true
+	}
+}
+
+func WithSplitFunc(split bufio.SplitFunc) Option {
+	return func(o *options) {
+		o.split = split
+	}
+}
+
+func WithLongLinePolicy(policy LongLinePolicy) Option {
+	return func(o *options) {
+		o.longLinePolicy = policy
+	}
+}
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+func WithRotationPolicy(policy RotationPolicy) Option {
+	return func(o *options) {
+		o.rotationPolicy = policy
+	}
+}
+
+func WithRotationCallback(callback func(RotationEvent)) Option {
+	return func(o *options) {
+		o.onRotate = callback
+	}
+}
+
+func WithIndexStride(stride int) Option {
+	return func(o *options) {
+		o.indexStride = stride
+	}
+}
+
+// WithPrefetch makes forward and backward scanners read up to chunks chunks ahead concurrently.
+// Scanners with prefetching must be closed.
+func WithPrefetch(chunks int) Option {
+	return func(o *options) {
+		o.prefetch = chunks
+	}
+}
+
+// WithAdaptiveChunkSize makes forward and backward scanners start reading with chunks of min bytes
+// and double the chunk size with every read, up to the chunk size of WithChunkSize.
+func WithAdaptiveChunkSize(min int) Option {
+	return func(o *options) {
+		o.adaptiveChunkSize = true
+		o.minChunkSize = min
+	}
+}
+
+// WithBufferPool makes forward and backward scanners borrow their buffers from pool, or from a shared
+// pool if it is nil, and return them on Close. Lines returned by a scanner are invalid after Close.
+func WithBufferPool(pool BufferPool) Option {
+	return func(o *options) {
+		if pool == nil {
+			pool = defaultBufferPool
+		}
+		o.bufferPool = pool
+	}
+}
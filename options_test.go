@@ -0,0 +1,173 @@
+package linescanner
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_NewOptions(t *testing.T) {
+	// when
+	o := newOptions(nil)
+
+	// then
+	assert.Equal(t, o.maxChunkSize, defaultMaxChunkSize)
+	assert.Equal(t, o.maxBufferSize, defaultMaxBufferSize)
+	assert.Equal(t, o.delimiter, defaultDelimiter)
+	assert.False(t, o.keepCarriageReturn)
+	assert.Nil(t, o.split)
+	assert.Equal(t, o.longLinePolicy, LongLineError)
+	assert.Equal(t, o.pollInterval, defaultPollInterval)
+	assert.Nil(t, o.validate())
+	assert.True(t, o.trimCarriageReturn())
+}
+
+func TestOptions_NewOptions_WithOptions(t *testing.T) {
+	// given
+	delimiter := []byte("--")
+
+	// when
+	o := newOptions([]Option{
+		WithChunkSize(16),
+		WithMaxBufferSize(64),
+		WithDelimiter(delimiter),
+		WithKeepCarriageReturn(),
+		WithSplitFunc(bufio.ScanWords),
+		WithLongLinePolicy(LongLineError),
+		WithPollInterval(time.Millisecond),
+	})
+	delimiter[0] = 'x'
+
+	// then
+	assert.Equal(t, o.maxChunkSize, 16)
+	assert.Equal(t, o.maxBufferSize, 64)
+	assert.Equal(t, o.delimiter, []byte("--"))
+	assert.True(t, o.keepCarriageReturn)
+	assert.NotNil(t, o.split)
+	assert.Equal(t, o.pollInterval, time.Millisecond)
+	assert.Nil(t, o.validate())
+}
+
+func TestOptions_Validate(t *testing.T) {
+	// given
+	tests := []struct {
+		opts []Option
+		err  error
+	}{
+		{[]Option{WithChunkSize(0)}, ErrInvalidMaxChunkSize},
+		{[]Option{WithMaxBufferSize(-1)}, ErrInvalidMaxBufferSize},
+		{[]Option{WithChunkSize(100), WithMaxBufferSize(10)}, ErrGreaterBufferSize},
+		{[]Option{WithDelimiter(nil)}, ErrEmptyDelimiter},
+		{[]Option{WithLongLinePolicy(LongLinePolicy(-1))}, ErrInvalidLongLinePolicy},
+		{[]Option{WithLongLinePolicy(LongLineFragment + 1)}, ErrInvalidLongLinePolicy},
+		{[]Option{WithPollInterval(-time.Second)}, nil},
+		{[]Option{WithRotationPolicy(RotationPolicy(-1))}, nil},
+		{[]Option{WithIndexStride(0)}, nil},
+		{[]Option{WithSplitFunc(bufio.ScanLines), WithLongLinePolicy(LongLineSkip)}, ErrSplitFuncUnsupported},
+	}
+
+	for _, test := range tests {
+		// when
+		o := newOptions(test.opts)
+
+		// then
+		assert.Equal(t, o.validate(), test.err)
+	}
+}
+
+func TestOptions_ValidateReads(t *testing.T) {
+	// given
+	tests := []struct {
+		opts []Option
+		err  error
+	}{
+		{nil, nil},
+		{[]Option{WithPrefetch(-1)}, ErrInvalidPrefetch},
+		{[]Option{WithAdaptiveChunkSize(0)}, ErrInvalidMinChunkSize},
+		{[]Option{WithChunkSize(4), WithAdaptiveChunkSize(8)}, ErrInvalidMinChunkSize},
+	}
+
+	for _, test := range tests {
+		// when
+		o := newOptions(test.opts)
+
+		// then
+		assert.Nil(t, o.validate())
+		assert.Equal(t, o.validateReads(), test.err)
+	}
+}
+
+func TestOptions_ValidateFollow(t *testing.T) {
+	// given
+	tests := []struct {
+		opts []Option
+		err  error
+	}{
+		{nil, nil},
+		{[]Option{WithPollInterval(0)}, ErrInvalidPollInterval},
+		{[]Option{WithPollInterval(-time.Second)}, ErrInvalidPollInterval},
+		{[]Option{WithRotationPolicy(RotationPolicy(-1))}, ErrInvalidRotationPolicy},
+		{[]Option{WithRotationPolicy(RotationDrain + 1)}, ErrInvalidRotationPolicy},
+	}
+
+	for _, test := range tests {
+		// when
+		o := newOptions(test.opts)
+
+		// then
+		assert.Equal(t, o.validateFollow(), test.err)
+	}
+}
+
+func TestOptions_ValidateIndex(t *testing.T) {
+	// when
+	o := newOptions([]Option{WithIndexStride(0)})
+
+	// then
+	assert.Equal(t, o.validateIndex(), ErrInvalidIndexStride)
+	o = newOptions(nil)
+	assert.Nil(t, o.validateIndex())
+}
+
+func TestOptions_IgnoredOptions(t *testing.T) {
+	// given
+	data := "ab\ncd\nef"
+	opts := []Option{WithPollInterval(0), WithRotationPolicy(RotationPolicy(-1)), WithIndexStride(0)}
+
+	// when
+	forward, forwardErr := TryNewForward(strings.NewReader(data), 0, opts...)
+	backward, backwardErr := TryNewBackward(strings.NewReader(data), len(data), opts...)
+	cursor, cursorErr := TryNewCursor(strings.NewReader(data), 0,
+		append(opts, WithPrefetch(-1), WithAdaptiveChunkSize(0), WithBufferPool(nil))...)
+
+	// then
+	assert.Nil(t, forwardErr)
+	assert.Nil(t, backwardErr)
+	assert.Nil(t, cursorErr)
+	line, err := forward.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+	line, err = backward.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ef")
+	line, err = cursor.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ab")
+}
+
+func TestOptions_TrimCarriageReturn(t *testing.T) {
+	// case 1
+	o := newOptions([]Option{WithKeepCarriageReturn()})
+	assert.False(t, o.trimCarriageReturn())
+
+	// case 2
+	o = newOptions([]Option{WithDelimiter([]byte{0})})
+	assert.False(t, o.trimCarriageReturn())
+
+	// case 3
+	o = newOptions([]Option{WithDelimiter([]byte("\n"))})
+	assert.True(t, o.trimCarriageReturn())
+}
@@ -0,0 +1,107 @@
+package linescanner
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Shard is a byte range [Start, End) of a reader whose ends are line starts.
+type Shard struct {
+	Start int64
+	End   int64
+}
+
+// Split divides the first size bytes of reader into n shards of about equal size, moving each
+// boundary forward to the next line start. Every line starts in exactly one shard; a shard is
+// empty when a line spans its whole range.
+func Split(reader io.ReaderAt, size int64, n int, opts ...Option) ([]Shard, error) {
+	if reader == nil {
+		return nil, ErrNilReader
+	}
+	if size < 0 {
+		return nil, ErrInvalidPosition
+	}
+	if n <= 0 {
+		return nil, ErrInvalidShardCount
+	}
+	if _, err := newForward(reader, 0, opts); err != nil {
+		return nil, err
+	}
+	reader = io.NewSectionReader(reader, 0, size)
+	opts = probeOptions(opts)
+	shards := make([]Shard, n)
+	for i := 1; i < n; i++ {
+		boundary := shards[i-1].Start
+		if position := size/int64(n)*int64(i) + size%int64(n)*int64(i)/int64(n); position > boundary {
+			start, err := nextLineStart(reader, size, position, opts)
+			if err != nil {
+				return nil, err
+			}
+			boundary = start
+		}
+		shards[i-1].End = boundary
+		shards[i].Start = boundary
+	}
+	shards[n-1].End = size
+	return shards, nil
+}
+
+// ParallelScan scans the shards of the first size bytes of reader with up to workers forward scanners
+// at once and calls fn with every line and the number of its shard. fn is called concurrently, and
+// line is only valid until it returns. The first error returned by fn or a scanner, or the end of ctx,
+// stops all workers.
+func ParallelScan(ctx context.Context, reader io.ReaderAt, size int64, workers int, fn func(shard int, line []byte) error, opts ...Option) error {
+	shards, err := Split(reader, size, workers, opts...)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i, shard := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := scanShard(ctx, reader, i, shard, fn, opts); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func scanShard(ctx context.Context, reader io.ReaderAt, i int, shard Shard, fn func(shard int, line []byte) error, opts []Option) error {
+	if shard.Start == shard.End {
+		return nil
+	}
+	forward, err := newForward(io.NewSectionReader(reader, 0, shard.End), shard.Start, opts)
+	if err != nil {
+		return err
+	}
+	defer forward.Close()
+	var fnErr error
+	err = forEachLine(forward, func(_ int, _ LineInfo, line []byte) bool {
+		if fnErr = ctx.Err(); fnErr != nil {
+			return false
+		}
+		fnErr = fn(i, line)
+		return fnErr == nil
+	})
+	if err != nil {
+		return err
+	}
+	return fnErr
+}
@@ -0,0 +1,210 @@
+package linescanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	// given
+	data := "aaaa\nbb\ncccccc\nd\neeeeeeee\nf"
+	reader := strings.NewReader(data)
+
+	// when
+	shards, err := Split(reader, reader.Size(), 4)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, shards, []Shard{{0, 8}, {8, 15}, {15, 26}, {26, 27}})
+}
+
+func TestSplit_LongLine(t *testing.T) {
+	// given
+	data := "a\nbbbbbbbbbbbbbbbbbbbbbbbb\nc\n"
+	reader := strings.NewReader(data)
+
+	// when
+	shards, err := Split(reader, reader.Size(), 4, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, shards, []Shard{{0, 27}, {27, 27}, {27, 27}, {27, 29}})
+}
+
+func TestSplit_MoreShardsThanBytes(t *testing.T) {
+	// given
+	data := "a\nb"
+	reader := strings.NewReader(data)
+
+	// when
+	shards, err := Split(reader, reader.Size(), 5)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, shards, []Shard{{0, 0}, {0, 2}, {2, 2}, {2, 2}, {2, 3}})
+}
+
+func TestSplit_Errors(t *testing.T) {
+	// given
+	reader := strings.NewReader("a\nb")
+
+	// when
+	_, err := Split(nil, 0, 1)
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = Split(reader, -1, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = Split(reader, reader.Size(), 0)
+
+	// then
+	assert.Equal(t, err, ErrInvalidShardCount)
+
+	// when
+	_, err = Split(reader, reader.Size(), 2, WithDelimiter(nil))
+
+	// then
+	assert.Equal(t, err, ErrEmptyDelimiter)
+}
+
+func TestParallelScan(t *testing.T) {
+	// given
+	var builder strings.Builder
+	var expected []string
+	for i := 0; i < 10000; i++ {
+		line := fmt.Sprintf("line %d %s", i, strings.Repeat("x", i%37))
+		builder.WriteString(line + "\r\n")
+		expected = append(expected, line)
+	}
+	data := builder.String()
+	reader := strings.NewReader(data)
+
+	for _, workers := range []int{1, 3, 8, 64} {
+		var mu sync.Mutex
+		var lines []string
+		shardLines := make(map[int][]string)
+
+		// when
+		err := ParallelScan(context.Background(), reader, reader.Size(), workers, func(shard int, line []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, string(line))
+			shardLines[shard] = append(shardLines[shard], string(line))
+			return nil
+		}, WithChunkSize(64), WithMaxBufferSize(128))
+
+		// then
+		assert.Nil(t, err)
+		assert.Len(t, lines, len(expected))
+		var ordered []string
+		for shard := 0; shard < workers; shard++ {
+			ordered = append(ordered, shardLines[shard]...)
+		}
+		assert.Equal(t, ordered, expected)
+	}
+}
+
+func TestParallelScan_OverlappingDelimiter(t *testing.T) {
+	tests := []struct {
+		data      string
+		delimiter string
+	}{
+		{"a----\nb", "--"},
+		{"a---b--c-----d-", "--"},
+		{"p1\n\n\np2\n\n\n\np3\n\n", "\n\n"},
+		{"xababaxabababx", "aba"},
+	}
+
+	for _, test := range tests {
+		// given
+		reader := strings.NewReader(test.data)
+		var expected []string
+		for line := range NewForward(reader, 0, WithDelimiter([]byte(test.delimiter))).Lines() {
+			expected = append(expected, line)
+		}
+
+		for workers := 1; workers <= len(test.data); workers++ {
+			var mu sync.Mutex
+			shardLines := make(map[int][]string)
+
+			// when
+			err := ParallelScan(context.Background(), reader, reader.Size(), workers, func(shard int, line []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				shardLines[shard] = append(shardLines[shard], string(line))
+				return nil
+			}, WithDelimiter([]byte(test.delimiter)), WithChunkSize(2))
+
+			// then
+			assert.Nil(t, err)
+			var ordered []string
+			for shard := 0; shard < workers; shard++ {
+				ordered = append(ordered, shardLines[shard]...)
+			}
+			assert.Equal(t, ordered, expected, "%q %d", test.data, workers)
+		}
+	}
+}
+
+func TestParallelScan_Error(t *testing.T) {
+	// given
+	data := strings.Repeat("line\n", 100000)
+	reader := strings.NewReader(data)
+	scanErr := errors.New("scan error")
+	var count atomic.Int64
+
+	// when
+	err := ParallelScan(context.Background(), reader, reader.Size(), 4, func(shard int, line []byte) error {
+		if count.Add(1) == 10 {
+			return scanErr
+		}
+		return nil
+	})
+
+	// then
+	assert.Equal(t, err, scanErr)
+	assert.Less(t, count.Load(), int64(100000))
+}
+
+func TestParallelScan_ReadError(t *testing.T) {
+	// given
+	data := "ab\ncdefgh\nij\n"
+	reader := strings.NewReader(data)
+
+	// when
+	err := ParallelScan(context.Background(), reader, reader.Size(), 2, func(shard int, line []byte) error {
+		return nil
+	}, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestParallelScan_Canceled(t *testing.T) {
+	// given
+	data := strings.Repeat("line\n", 100)
+	reader := strings.NewReader(data)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := ParallelScan(ctx, reader, reader.Size(), 2, func(shard int, line []byte) error {
+		return nil
+	})
+
+	// then
+	assert.Equal(t, err, context.Canceled)
+}
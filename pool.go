@@ -0,0 +1,56 @@
+package linescanner
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// BufferPool lends chunk and line buffers to scanners. Get returns a buffer of length size, which
+// is not used by anyone else until it is passed to Put.
+type BufferPool interface {
+	Get(size int) []byte
+	Put(buffer []byte)
+}
+
+type syncBufferPool struct {
+	classes [bits.UintSize]sync.Pool
+}
+
+var defaultBufferPool = NewBufferPool()
+
+// NewBufferPool returns a BufferPool backed by a sync.Pool for every power of two size.
+func NewBufferPool() BufferPool {
+	return &syncBufferPool{}
+}
+
+func (p *syncBufferPool) Get(size int) []byte {
+	class := 0
+	if size > 1 {
+		class = bits.Len(uint(size - 1))
+	}
+	if buffer, ok := p.classes[class].Get().(*[]byte); ok {
+		return (*buffer)[:size]
+	}
+	return make([]byte, size, 1<<class)
+}
+
+func (p *syncBufferPool) Put(buffer []byte) {
+	if cap(buffer) == 0 {
+		return
+	}
+	buffer = buffer[:0]
+	p.classes[bits.Len(uint(cap(buffer)))-1].Put(&buffer)
+}
+
+func allocate(pool BufferPool, size int) []byte {
+	if pool == nil {
+		return make([]byte, size)
+	}
+	return pool.Get(size)
+}
+
+func release(pool BufferPool, buffer []byte) {
+	if pool != nil && cap(buffer) > 0 {
+		pool.Put(buffer[:0])
+	}
+}
@@ -0,0 +1,193 @@
+package linescanner
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkedPool is a BufferPool that records the buffers it lends, so that tests can find buffers
+// lent twice, returned twice or never returned.
+type checkedPool struct {
+	mu       sync.Mutex
+	free     [][]byte
+	lent     map[*byte]bool
+	gets     int
+	errs     []string
+	released int
+}
+
+func newCheckedPool() *checkedPool {
+	return &checkedPool{lent: make(map[*byte]bool)}
+}
+
+func (p *checkedPool) Get(size int) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.gets++
+	var buffer []byte
+	for i, free := range p.free {
+		if cap(free) >= size {
+			buffer = free[:size]
+			p.free = append(p.free[:i], p.free[i+1:]...)
+			break
+		}
+	}
+	if buffer == nil {
+		buffer = make([]byte, size)
+	}
+	key := unsafe.SliceData(buffer[:cap(buffer)])
+	if p.lent[key] {
+		p.errs = append(p.errs, "buffer lent twice")
+	}
+	p.lent[key] = true
+	return buffer
+}
+
+func (p *checkedPool) Put(buffer []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := unsafe.SliceData(buffer[:cap(buffer)])
+	if !p.lent[key] {
+		p.errs = append(p.errs, "buffer returned but not lent")
+	}
+	delete(p.lent, key)
+	p.released++
+	p.free = append(p.free, buffer)
+}
+
+func TestBufferPool(t *testing.T) {
+	// given
+	pool := NewBufferPool()
+
+	for _, size := range []int{0, 1, 2, 3, 4096, 5000} {
+		// when
+		buffer := pool.Get(size)
+
+		// then
+		assert.Len(t, buffer, size)
+		assert.GreaterOrEqual(t, cap(buffer), size)
+		pool.Put(buffer)
+	}
+}
+
+func TestBufferPool_Scanners(t *testing.T) {
+	// given
+	data := prefetchData(300)
+	pool := newCheckedPool()
+
+	// when
+	var wg sync.WaitGroup
+	results := make([][]lineResult, 32)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := []Option{WithChunkSize(8 + i), WithMaxBufferSize(64), WithBufferPool(pool)}
+			var scanner interface {
+				ByteLineScanner
+				Close() error
+			}
+			if i%2 == 0 {
+				scanner = NewForward(strings.NewReader(data), 0, opts...)
+			} else {
+				scanner = NewBackward(strings.NewReader(data), len(data), opts...)
+			}
+			results[i] = scanResults(scanner)
+			scanner.Close()
+		}()
+	}
+	wg.Wait()
+
+	// then
+	forward := scanResults(NewForward(strings.NewReader(data), 0))
+	backward := scanResults(NewBackward(strings.NewReader(data), len(data)))
+	for i, result := range results {
+		if i%2 == 0 {
+			assert.Equal(t, result, forward, i)
+		} else {
+			assert.Equal(t, result, backward, i)
+		}
+	}
+	assert.Empty(t, pool.errs)
+	assert.Empty(t, pool.lent)
+	assert.Greater(t, pool.gets, len(results))
+}
+
+func TestBufferPool_NoAliasing(t *testing.T) {
+	// given
+	pool := newCheckedPool()
+	lines := make([]string, 20)
+	scanners := make([]*forward, len(lines))
+	for i := range scanners {
+		lines[i] = fmt.Sprintf("scanner %d %s", i, strings.Repeat("x", i*3))
+		scanners[i] = NewForward(strings.NewReader(strings.Repeat(lines[i]+"\n", 50)), 0, WithChunkSize(4), WithBufferPool(pool))
+	}
+
+	// when
+	held := make([][]byte, len(scanners))
+	for round := 0; round < 50; round++ {
+		for i, scanner := range scanners {
+			line, err := scanner.LineBytes()
+			assert.Nil(t, err)
+			held[i] = line
+		}
+
+		// then
+		for i, line := range held {
+			assert.Equal(t, string(line), lines[i])
+		}
+	}
+	for _, scanner := range scanners {
+		assert.Nil(t, scanner.Close())
+	}
+	assert.Empty(t, pool.errs)
+	assert.Empty(t, pool.lent)
+}
+
+func TestBufferPool_Close(t *testing.T) {
+	// given
+	pool := newCheckedPool()
+	data := "a\nbbbbbbbbbbbb\nc\n"
+	forward := NewForward(strings.NewReader(data), 0, WithChunkSize(2), WithBufferPool(pool))
+	backward := NewBackward(strings.NewReader(data), len(data), WithChunkSize(2), WithBufferPool(pool))
+	for _, scanner := range []ByteLineScanner{forward, backward} {
+		for i := 0; i < 2; i++ {
+			_, err := scanner.Line()
+			assert.Nil(t, err)
+		}
+	}
+	assert.NotEmpty(t, pool.lent)
+
+	// when
+	assert.Nil(t, forward.Close())
+	assert.Nil(t, backward.Close())
+
+	// then
+	assert.Empty(t, pool.errs)
+	assert.Empty(t, pool.lent)
+	_, err := forward.Line()
+	assert.Equal(t, err, ErrScannerClosed)
+	_, err = backward.Line()
+	assert.Equal(t, err, ErrScannerClosed)
+}
+
+func TestBufferPool_Default(t *testing.T) {
+	// given
+	data := prefetchData(100)
+
+	for i := 0; i < 3; i++ {
+		forward := NewForward(strings.NewReader(data), 0, WithChunkSize(16), WithBufferPool(nil))
+
+		// when
+		results := scanResults(forward)
+
+		// then
+		assert.Nil(t, forward.Close())
+		assert.Equal(t, results, scanResults(NewForward(strings.NewReader(data), 0)))
+	}
+}
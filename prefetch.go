@@ -0,0 +1,137 @@
+package linescanner
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+type fetch struct {
+	offset int64
+	data   []byte
+	n      int
+	err    error
+	done   chan struct{}
+}
+
+// prefetcher is an io.ReaderAt that reads up to depth chunks ahead of a scanner in its direction, so
+// that a slow reader is read concurrently with scanning. A read it did not predict is served directly
+// and discards the fetches ahead of it. Errors are returned with the chunk they belong to, so that
+// the scanner reports them at the same line as without prefetching.
+type prefetcher struct {
+	reader            io.ReaderAt
+	direction         Direction
+	maxChunkSize      int
+	adaptiveChunkSize bool
+	depth             int
+
+	fetches []*fetch
+	free    [][]byte
+	failed  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPrefetcher(reader io.ReaderAt, direction Direction, maxChunkSize int, adaptiveChunkSize bool, depth int) *prefetcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &prefetcher{
+		reader:            reader,
+		direction:         direction,
+		maxChunkSize:      maxChunkSize,
+		adaptiveChunkSize: adaptiveChunkSize,
+		depth:             depth,
+		ctx:               ctx,
+		cancel:            cancel,
+	}
+}
+
+func (p *prefetcher) start(offset int64, size int) *fetch {
+	var data []byte
+	if n := len(p.free); n > 0 && cap(p.free[n-1]) >= size {
+		data = p.free[n-1][:size]
+		p.free = p.free[:n-1]
+	} else {
+		data = make([]byte, size)
+	}
+	f := &fetch{offset: offset, data: data, done: make(chan struct{})}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(f.done)
+		if f.err = p.ctx.Err(); f.err != nil {
+			return
+		}
+		f.n, f.err = p.reader.ReadAt(f.data, f.offset)
+	}()
+	return f
+}
+
+// next returns the chunk the scanner reads after the one at offset, or false at the start of the reader.
+func (p *prefetcher) next(offset int64, size int) (int64, int, bool) {
+	nextSize := p.maxChunkSize
+	if p.adaptiveChunkSize {
+		nextSize = growChunkSize(size, p.maxChunkSize)
+	}
+	if p.direction == Forward {
+		return offset + int64(size), nextSize, true
+	}
+	nextSize = int(minInt64(offset, int64(nextSize)))
+	return offset - int64(nextSize), nextSize, nextSize > 0
+}
+
+// fill starts the fetches after the one at offset, unless the last read failed, as at the end of
+// a reader that a Follower polls.
+func (p *prefetcher) fill(offset int64, size int) {
+	if p.failed {
+		return
+	}
+	if last := len(p.fetches) - 1; last >= 0 {
+		offset, size = p.fetches[last].offset, len(p.fetches[last].data)
+	}
+	for len(p.fetches) < p.depth {
+		var ok bool
+		if offset, size, ok = p.next(offset, size); !ok {
+			return
+		}
+		p.fetches = append(p.fetches, p.start(offset, size))
+	}
+}
+
+func (p *prefetcher) ReadAt(b []byte, off int64) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, ErrScannerClosed
+	}
+	var f *fetch
+	if len(p.fetches) > 0 && p.fetches[0].offset == off && len(p.fetches[0].data) == len(b) {
+		f = p.fetches[0]
+		p.fetches = p.fetches[1:]
+	} else {
+		p.fetches = nil
+		f = p.start(off, len(b))
+	}
+	p.fill(off, len(b))
+	<-f.done
+	n := copy(b, f.data[:f.n])
+	err := f.err
+	if err == context.Canceled {
+		err = ErrScannerClosed
+	}
+	p.failed = err != nil
+	if p.failed {
+		p.fetches = nil
+	} else {
+		p.free = append(p.free, f.data)
+	}
+	return n, err
+}
+
+// Close cancels the reads that have not started and waits for the rest to finish, after which the
+// underlying reader may be closed.
+func (p *prefetcher) Close() error {
+	p.cancel()
+	p.fetches = nil
+	p.wg.Wait()
+	return nil
+}
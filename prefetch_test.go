@@ -0,0 +1,315 @@
+package linescanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type latencyReader struct {
+	io.ReaderAt
+	latency time.Duration
+	failAt  int64
+	err     error
+
+	mu        sync.Mutex
+	reads     int
+	active    int
+	maxActive int
+}
+
+func (r *latencyReader) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	r.reads++
+	r.active++
+	r.maxActive = maxInt(r.maxActive, r.active)
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.active--
+		r.mu.Unlock()
+	}()
+	time.Sleep(r.latency)
+	if r.err != nil && off <= r.failAt && r.failAt < off+int64(len(p)) {
+		return 0, r.err
+	}
+	return r.ReaderAt.ReadAt(p, off)
+}
+
+func prefetchData(lines int) string {
+	return formatLines(lines, "line %d %s\n", func(i int) []any {
+		return []any{i, strings.Repeat("x", i%13)}
+	})
+}
+
+type lineResult struct {
+	line string
+	err  error
+}
+
+func scanResults(scanner ByteLineScanner) []lineResult {
+	var results []lineResult
+	for {
+		line, err := scanner.Line()
+		results = append(results, lineResult{line, err})
+		if err != nil {
+			return results
+		}
+	}
+}
+
+func TestPrefetch_Forward(t *testing.T) {
+	// given
+	data := prefetchData(200)
+
+	for _, depth := range []int{1, 2, 8} {
+		reader := &latencyReader{ReaderAt: strings.NewReader(data), latency: 100 * time.Microsecond}
+		forward, err := TryNewForward(reader, 0, WithChunkSize(16), WithPrefetch(depth))
+		assert.Nil(t, err)
+
+		// when
+		results := scanResults(forward)
+
+		// then
+		assert.Nil(t, forward.Close())
+		assert.Equal(t, results, scanResults(NewForward(strings.NewReader(data), 0, WithChunkSize(16))), depth)
+		assert.Greater(t, reader.maxActive, 1)
+		assert.LessOrEqual(t, reader.maxActive, depth+1)
+		assert.Equal(t, reader.active, 0)
+	}
+}
+
+func TestPrefetch_Backward(t *testing.T) {
+	// given
+	data := prefetchData(200)
+
+	for _, depth := range []int{1, 2, 8} {
+		reader := &latencyReader{ReaderAt: strings.NewReader(data), latency: 100 * time.Microsecond}
+		backward, err := TryNewBackward(reader, len(data), WithChunkSize(16), WithPrefetch(depth))
+		assert.Nil(t, err)
+
+		// when
+		results := scanResults(backward)
+
+		// then
+		assert.Nil(t, backward.Close())
+		assert.Equal(t, results, scanResults(NewBackward(strings.NewReader(data), len(data), WithChunkSize(16))), depth)
+		assert.Greater(t, reader.maxActive, 1)
+		assert.LessOrEqual(t, reader.maxActive, depth+1)
+	}
+}
+
+func TestPrefetch_AdaptiveChunkSize(t *testing.T) {
+	// given
+	data := prefetchData(200)
+	reader := &latencyReader{ReaderAt: strings.NewReader(data)}
+	forward := NewForward(reader, 0, WithChunkSize(256), WithAdaptiveChunkSize(4), WithPrefetch(4))
+	backward := NewBackward(reader, len(data), WithChunkSize(256), WithAdaptiveChunkSize(4), WithPrefetch(4))
+
+	// when
+	forwardResults := scanResults(forward)
+	backwardResults := scanResults(backward)
+
+	// then
+	assert.Nil(t, forward.Close())
+	assert.Nil(t, backward.Close())
+	assert.Equal(t, forwardResults, scanResults(NewForward(strings.NewReader(data), 0)))
+	assert.Equal(t, backwardResults, scanResults(NewBackward(strings.NewReader(data), len(data))))
+	assert.Less(t, reader.reads, forward.Stats().Reads+backward.Stats().Reads+16)
+}
+
+func TestPrefetch_Concurrent(t *testing.T) {
+	// given
+	data := prefetchData(100)
+	reader := &latencyReader{ReaderAt: strings.NewReader(data), latency: 5 * time.Millisecond}
+	forward := NewForward(reader, 0, WithChunkSize(64), WithPrefetch(8))
+	defer forward.Close()
+	start := time.Now()
+
+	// when
+	results := scanResults(forward)
+
+	// then
+	assert.Len(t, results, 101)
+	reads := (len(data) + 63) / 64
+	assert.Less(t, time.Since(start), time.Duration(reads)*5*time.Millisecond/2)
+}
+
+func TestPrefetch_ReadError(t *testing.T) {
+	// given
+	data := prefetchData(50)
+	readErr := errors.New("read error")
+	failAt := int64(strings.Index(data, "line 30 "))
+
+	for _, newScanner := range []func(reader io.ReaderAt, opts ...Option) ByteLineScanner{
+		func(reader io.ReaderAt, opts ...Option) ByteLineScanner {
+			return NewForward(reader, 0, opts...)
+		},
+		func(reader io.ReaderAt, opts ...Option) ByteLineScanner {
+			return NewBackward(reader, len(data), opts...)
+		},
+	} {
+		reader := &latencyReader{ReaderAt: strings.NewReader(data), failAt: failAt, err: readErr}
+		scanner := newScanner(reader, WithChunkSize(8), WithPrefetch(4))
+
+		// when
+		results := scanResults(scanner)
+
+		// then
+		expected := scanResults(newScanner(&latencyReader{ReaderAt: strings.NewReader(data), failAt: failAt, err: readErr}, WithChunkSize(8)))
+		assert.Equal(t, results, expected)
+		assert.ErrorIs(t, results[len(results)-1].err, readErr)
+		assert.Greater(t, len(results), 10)
+	}
+}
+
+func TestPrefetch_Close(t *testing.T) {
+	// given
+	data := prefetchData(100)
+	reader := &latencyReader{ReaderAt: strings.NewReader(data), latency: time.Millisecond}
+	forward := NewForward(reader, 0, WithChunkSize(16), WithPrefetch(8))
+	_, err := forward.Line()
+	assert.Nil(t, err)
+
+	// when
+	err = forward.Close()
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, reader.active, 0)
+	for {
+		if _, err = forward.Line(); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, ErrScannerClosed)
+}
+
+func TestPrefetch_Helpers(t *testing.T) {
+	// given
+	data := seekData(200)
+	size := int64(len(data))
+
+	for name, helper := range map[string]func(reader io.ReaderAt, opts ...Option) error{
+		"Head": func(reader io.ReaderAt, opts ...Option) error {
+			_, err := Head(reader, 1, opts...)
+			return err
+		},
+		"Range": func(reader io.ReaderAt, opts ...Option) error {
+			_, err := Range(reader, 10, 20, opts...)
+			return err
+		},
+		"Between": func(reader io.ReaderAt, opts ...Option) error {
+			_, err := Between(reader, 100, 200, opts...)
+			return err
+		},
+		"Tail": func(reader io.ReaderAt, opts ...Option) error {
+			_, err := Tail(reader, size, 3, opts...)
+			return err
+		},
+		"SearchLines": func(reader io.ReaderAt, opts ...Option) error {
+			_, err := SearchLines(reader, size, func(line []byte) bool {
+				return string(line) < "2024-05-01T12:03"
+			}, opts...)
+			return err
+		},
+		"SeekTime": func(reader io.ReaderAt, opts ...Option) error {
+			forward, err := SeekTime(reader, size, seekBase.Add(time.Minute), ParseRFC3339, opts...)
+			if err != nil {
+				return err
+			}
+			return forward.Close()
+		},
+		"Index": func(reader io.ReaderAt, opts ...Option) error {
+			index, err := NewIndex(reader, append(opts, WithIndexStride(16))...)
+			if err != nil {
+				return err
+			}
+			offset, err := index.OffsetOfLine(100)
+			if err != nil {
+				return err
+			}
+			_, err = index.LineOfOffset(offset)
+			return err
+		},
+		"ParallelScan": func(reader io.ReaderAt, opts ...Option) error {
+			return ParallelScan(context.Background(), reader, size, 4, func(int, []byte) error {
+				return nil
+			}, opts...)
+		},
+	} {
+		reader := &latencyReader{ReaderAt: strings.NewReader(data), latency: time.Millisecond}
+		pool := newCheckedPool()
+
+		// when
+		err := helper(reader, WithChunkSize(64), WithPrefetch(4), WithBufferPool(pool))
+
+		// then
+		assert.Nil(t, err, name)
+		reader.mu.Lock()
+		assert.Equal(t, reader.active, 0, name)
+		reader.mu.Unlock()
+		assert.Empty(t, pool.errs, name)
+		assert.Empty(t, pool.lent, name)
+	}
+}
+
+func TestPrefetch_Follower(t *testing.T) {
+	// given
+	reader := &growingReader{data: []byte("ab\ncd")}
+	follower := NewFollower(reader, 0, WithChunkSize(2), WithMaxBufferSize(8), WithPollInterval(time.Millisecond), WithPrefetch(4))
+	defer follower.Close()
+	_, err := follower.Line(context.Background())
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = follower.Line(ctx)
+	assert.Equal(t, err, context.DeadlineExceeded)
+	reads := reader.reads
+
+	// when
+	reader.Append("\nef\n")
+	line, err := follower.Line(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, line, "cd")
+	line, err = follower.Line(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, line, "ef")
+	assert.Less(t, reads, 30)
+}
+
+func TestPrefetch_UnpredictedRead(t *testing.T) {
+	// given
+	data := "a\nbb\nccc\ndddd\n"
+	reader := strings.NewReader(data)
+	prefetcher := newPrefetcher(reader, Forward, 4, false, 2)
+	defer prefetcher.Close()
+	buffer := make([]byte, 4)
+
+	for _, offset := range []int64{0, 4, 2, 6, 10} {
+		// when
+		n, err := prefetcher.ReadAt(buffer, offset)
+
+		// then
+		expected := make([]byte, 4)
+		expectedN, expectedErr := reader.ReadAt(expected, offset)
+		assert.Equal(t, err, expectedErr)
+		assert.Equal(t, buffer[:n], expected[:expectedN])
+	}
+}
+
+func TestPrefetch_ErrInvalidPrefetch(t *testing.T) {
+	// when
+	_, err := TryNewForward(strings.NewReader(""), 0, WithPrefetch(-1))
+
+	// then
+	assert.Equal(t, err, ErrInvalidPrefetch)
+}
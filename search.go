@@ -0,0 +1,131 @@
+package linescanner
+
+import (
+	"bytes"
+	"io"
+)
+
+// SearchLines returns the offset of the first line in the first size bytes of reader for which
+// less is false, or size if there is none. Like sort.Search, it expects less to be true for
+// a prefix of the lines and false for the rest. Long lines are passed to less truncated.
+func SearchLines(reader io.ReaderAt, size int64, less func(line []byte) bool, opts ...Option) (int64, error) {
+	if reader == nil {
+		return 0, ErrNilReader
+	}
+	if size < 0 {
+		return 0, ErrInvalidPosition
+	}
+	return searchLines(io.NewSectionReader(reader, 0, size), size, func(_ int64, line []byte) (bool, error) {
+		return less(line), nil
+	}, probeOptions(opts))
+}
+
+// probeOptions truncates long lines, so that any line can be read to find the next one.
+func probeOptions(opts []Option) []Option {
+	return append(opts[:len(opts):len(opts)], WithLongLinePolicy(LongLineTruncate))
+}
+
+func searchLines(reader io.ReaderAt, size int64, less func(start int64, line []byte) (bool, error), opts []Option) (int64, error) {
+	lo, hi := int64(0), size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, err := nextLineStart(reader, size, mid, opts)
+		if err != nil {
+			return 0, err
+		}
+		if start >= hi {
+			if start, err = lineStart(reader, mid, opts); err != nil {
+				return 0, err
+			}
+		}
+		line, next, err := readLine(reader, size, start, opts)
+		if err != nil {
+			return 0, err
+		}
+		before, err := less(start, line)
+		if err != nil {
+			return 0, err
+		}
+		if before {
+			lo = next
+		} else {
+			hi = start
+		}
+	}
+	return lo, nil
+}
+
+// nextLineStart returns the start of the first line at or after position. A delimiter can overlap
+// itself, like "--" in "---", so the bytes before position do not show whether a line starts there.
+// Lines are scanned instead from a point that no delimiter spans, where scanning from the start of
+// reader would find the same delimiters.
+func nextLineStart(reader io.ReaderAt, size int64, position int64, opts []Option) (int64, error) {
+	if position == 0 {
+		return 0, nil
+	}
+	delimiter := newOptions(opts).delimiter
+	start := maxInt64(position-int64(len(delimiter)), 0)
+	for start > 0 {
+		spanning, ok, err := spanningDelimiter(reader, size, start, delimiter)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		start = spanning
+	}
+	forward, err := newForward(reader, start, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer forward.Close()
+	for {
+		if _, err := forward.LineBytes(); err != nil && err != io.EOF {
+			return 0, err
+		}
+		next := forward.Position64()
+		if next < 0 {
+			return size, nil
+		}
+		if next >= position {
+			return next, nil
+		}
+	}
+}
+
+// spanningDelimiter returns the start of the first delimiter that starts before position and ends after it.
+func spanningDelimiter(reader io.ReaderAt, size int64, position int64, delimiter []byte) (int64, bool, error) {
+	windowStart := maxInt64(position-int64(len(delimiter))+1, 0)
+	windowEnd := minInt64(position+int64(len(delimiter))-1, size)
+	if windowEnd-windowStart < int64(len(delimiter)) {
+		return 0, false, nil
+	}
+	window := make([]byte, windowEnd-windowStart)
+	if err := readFullAt(reader, window, windowStart); err != nil {
+		return 0, false, err
+	}
+	i := bytes.Index(window, delimiter)
+	if i < 0 || windowStart+int64(i) >= position {
+		return 0, false, nil
+	}
+	return windowStart + int64(i), true, nil
+}
+
+// readLine returns a copy of the line starting at start and the start of the line after it.
+func readLine(reader io.ReaderAt, size int64, start int64, opts []Option) ([]byte, int64, error) {
+	forward, err := newForward(reader, start, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer forward.Close()
+	line, err := forward.LineBytes()
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	next := forward.Position64()
+	if next < 0 {
+		next = size
+	}
+	return bytes.Clone(line), next, nil
+}
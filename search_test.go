@@ -0,0 +1,152 @@
+package linescanner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchData(lines int) string {
+	return formatLines(lines, "%05d\n", func(i int) []any {
+		return []any{i * 2}
+	})
+}
+
+func lessThan(target string) func(line []byte) bool {
+	return func(line []byte) bool {
+		return string(line) < target
+	}
+}
+
+func TestSearchLines(t *testing.T) {
+	// given
+	data := searchData(100)
+	reader := strings.NewReader(data)
+
+	for target := -1; target <= 200; target++ {
+		key := fmt.Sprintf("%05d", target)
+		if target < 0 {
+			key = ""
+		}
+		expected := int64(strings.Index(data, fmt.Sprintf("%05d\n", (target+1)/2*2)))
+		if target > 198 {
+			expected = int64(len(data))
+		}
+		if target < 0 {
+			expected = 0
+		}
+
+		// when
+		offset, err := SearchLines(reader, reader.Size(), lessThan(key), WithChunkSize(4), WithMaxBufferSize(16))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, offset, expected, key)
+	}
+}
+
+func TestSearchLines_VaryingLineLength(t *testing.T) {
+	// given
+	data := "a\nbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\nc\nd\ne\nffffffffffffffff\r\ng"
+	reader := strings.NewReader(data)
+
+	for _, target := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		expected := int64(len(data))
+		if i := strings.Index(data, target); i >= 0 {
+			expected = int64(i)
+		}
+
+		// when
+		offset, err := SearchLines(reader, reader.Size(), lessThan(target))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, offset, expected, target)
+	}
+}
+
+func TestSearchLines_LongLine(t *testing.T) {
+	// given
+	data := "aaa\nbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\nccc\n"
+	reader := strings.NewReader(data)
+
+	// when
+	offset, err := SearchLines(reader, reader.Size(), lessThan("c"), WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(strings.Index(data, "ccc")))
+}
+
+func TestSearchLines_Size(t *testing.T) {
+	// given
+	data := "a\nb\nc\nd\n"
+	reader := strings.NewReader(data)
+
+	// when
+	offset, err := SearchLines(reader, 4, lessThan("c"))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(4))
+}
+
+func TestSearchLines_Delimiter(t *testing.T) {
+	// given
+	data := "a\x00b\x00c"
+	reader := strings.NewReader(data)
+
+	// when
+	offset, err := SearchLines(reader, reader.Size(), lessThan("b"), WithDelimiter([]byte{0}))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(2))
+}
+
+func TestSearchLines_Empty(t *testing.T) {
+	// when
+	offset, err := SearchLines(strings.NewReader(""), 0, func(line []byte) bool {
+		return true
+	})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(0))
+}
+
+func TestSearchLines_Errors(t *testing.T) {
+	// when
+	_, err := SearchLines(nil, 0, lessThan("a"))
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = SearchLines(strings.NewReader("a\nb"), -1, lessThan("a"))
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = SearchLines(strings.NewReader("a\nb"), 10, lessThan("b"))
+
+	// then
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+}
+
+func TestSearchLines_Reads(t *testing.T) {
+	// given
+	data := searchData(50000)
+	reader := &countingReader{ReaderAt: strings.NewReader(data)}
+
+	// when
+	offset, err := SearchLines(reader, int64(len(data)), lessThan("12345"), WithChunkSize(64), WithMaxBufferSize(64))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, offset, int64(strings.Index(data, "12346\n")))
+	assert.Less(t, reader.reads, 200)
+}
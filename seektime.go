@@ -0,0 +1,180 @@
+package linescanner
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+const maxTimeProbeLines = 1000
+
+// SeekTime returns a forward scanner positioned at the first line of the first size bytes of reader
+// whose timestamp is not before t. A line without a timestamp, like a continuation line or a stack
+// trace, takes the timestamp of the closest line before it that has one.
+func SeekTime(reader io.ReaderAt, size int64, t time.Time, parse func(line []byte) (time.Time, bool), opts ...Option) (*forward, error) {
+	if reader == nil {
+		return nil, ErrNilReader
+	}
+	if size < 0 {
+		return nil, ErrInvalidPosition
+	}
+	if _, err := newForward(reader, 0, opts); err != nil {
+		return nil, err
+	}
+	probe := &timeProbe{
+		reader: io.NewSectionReader(reader, 0, size),
+		opts:   probeOptions(opts),
+		parse:  parse,
+	}
+	offset, err := searchLines(probe.reader, size, func(start int64, line []byte) (bool, error) {
+		timestamp, err := probe.timestamp(start, line)
+		if err != nil {
+			return false, err
+		}
+		return timestamp.Before(t), nil
+	}, probe.opts)
+	if err != nil {
+		return nil, err
+	}
+	return newForward(reader, offset, opts)
+}
+
+type timeProbe struct {
+	reader io.ReaderAt
+	opts   []Option
+	parse  func(line []byte) (time.Time, bool)
+}
+
+// timestamp returns the timestamp of the line at start, taken from the closest line before it
+// that has one, or else from the closest line after it.
+func (p *timeProbe) timestamp(start int64, line []byte) (time.Time, error) {
+	if timestamp, ok := p.parse(line); ok {
+		return timestamp, nil
+	}
+	if timestamp, ok, err := p.previous(start); err != nil || ok {
+		return timestamp, err
+	}
+	if timestamp, ok, err := p.next(start); err != nil || ok {
+		return timestamp, err
+	}
+	return time.Time{}, &ScanError{Op: opSeek, Direction: Forward, Offset: start, ChunkOffset: start, Err: ErrNoTimestamp}
+}
+
+func (p *timeProbe) previous(start int64) (time.Time, bool, error) {
+	if start == 0 {
+		return time.Time{}, false, nil
+	}
+	backward, err := newBackward(p.reader, start, p.opts)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer backward.Close()
+	for i := 0; i <= maxTimeProbeLines; i++ {
+		line, err := backward.LineBytes()
+		if err != nil && err != io.EOF {
+			return time.Time{}, false, err
+		}
+		if backward.noLine {
+			break
+		}
+		if timestamp, ok := p.parse(line); ok {
+			return timestamp, true, nil
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+func (p *timeProbe) next(start int64) (time.Time, bool, error) {
+	forward, err := newForward(p.reader, start, p.opts)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer forward.Close()
+	for i := 0; i <= maxTimeProbeLines; i++ {
+		line, err := forward.LineBytes()
+		if err != nil && err != io.EOF {
+			return time.Time{}, false, err
+		}
+		if timestamp, ok := p.parse(line); ok && i > 0 {
+			return timestamp, true, nil
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+// ParseRFC3339 parses an RFC 3339 timestamp at the start of a line.
+func ParseRFC3339(line []byte) (time.Time, bool) {
+	timestamp, err := time.Parse(time.RFC3339Nano, string(firstField(line)))
+	return timestamp, err == nil
+}
+
+// ParseRFC5424 parses the timestamp of an RFC 5424 syslog line, like "<34>1 2003-10-11T22:14:15.003Z host ...".
+func ParseRFC5424(line []byte) (time.Time, bool) {
+	line, ok := cutPriority(line)
+	if !ok {
+		return time.Time{}, false
+	}
+	version := firstField(line)
+	if len(version) == 0 || len(version) == len(line) {
+		return time.Time{}, false
+	}
+	return ParseRFC3339(line[len(version)+1:])
+}
+
+// RFC3164Parser returns a parser for RFC 3164 syslog lines, like "<34>Oct 11 22:14:15 host ...".
+// The format has no year, so the given year and location are used.
+func RFC3164Parser(year int, location *time.Location) func(line []byte) (time.Time, bool) {
+	return func(line []byte) (time.Time, bool) {
+		if priority, ok := cutPriority(line); ok {
+			line = priority
+		}
+		if len(line) < len(time.Stamp) {
+			return time.Time{}, false
+		}
+		timestamp, err := time.Parse(time.Stamp, string(line[:len(time.Stamp)]))
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Date(year, timestamp.Month(), timestamp.Day(),
+			timestamp.Hour(), timestamp.Minute(), timestamp.Second(), 0, location), true
+	}
+}
+
+// ParseCommonLog parses the bracketed timestamp of Apache and nginx access log lines,
+// like `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326`.
+func ParseCommonLog(line []byte) (time.Time, bool) {
+	start := bytes.IndexByte(line, '[')
+	if start < 0 {
+		return time.Time{}, false
+	}
+	end := bytes.IndexByte(line[start:], ']')
+	if end < 0 {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse("02/Jan/2006:15:04:05 -0700", string(line[start+1:start+end]))
+	return timestamp, err == nil
+}
+
+func firstField(line []byte) []byte {
+	if i := bytes.IndexByte(line, ' '); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+func cutPriority(line []byte) ([]byte, bool) {
+	if len(line) == 0 || line[0] != '<' {
+		return nil, false
+	}
+	end := bytes.IndexByte(line, '>')
+	if end < 2 {
+		return nil, false
+	}
+	return line[end+1:], true
+}
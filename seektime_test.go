@@ -0,0 +1,184 @@
+package linescanner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var seekBase = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func seekData(entries int) string {
+	return formatLines(entries, "%s entry %d\n%s", func(i int) []any {
+		trace := ""
+		if i%3 == 0 {
+			trace = "  at trace one\n  at trace two\n"
+		}
+		return []any{seekBase.Add(time.Duration(i*2) * time.Second).Format(time.RFC3339), i, trace}
+	})
+}
+
+func TestSeekTime(t *testing.T) {
+	// given
+	data := seekData(50)
+	reader := strings.NewReader(data)
+
+	for seconds := -1; seconds <= 100; seconds++ {
+		target := seekBase.Add(time.Duration(seconds) * time.Second)
+		entry := (seconds + 1) / 2
+		if entry < 0 {
+			entry = 0
+		}
+
+		// when
+		forward, err := SeekTime(reader, reader.Size(), target, ParseRFC3339, WithChunkSize(16), WithMaxBufferSize(64))
+
+		// then
+		assert.Nil(t, err)
+		line, err := forward.Line()
+		if entry >= 50 {
+			assert.Equal(t, err, io.EOF)
+			assert.Empty(t, line)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.True(t, strings.HasSuffix(line, fmt.Sprintf(" entry %d", entry)), "%d: %s", seconds, line)
+	}
+}
+
+func TestSeekTime_LeadingLinesWithoutTimestamp(t *testing.T) {
+	// given
+	data := "header\nheader\n2024-05-01T12:00:00Z a\n2024-05-01T12:00:05Z b\n"
+	reader := strings.NewReader(data)
+
+	// when
+	forward, err := SeekTime(reader, reader.Size(), seekBase.Add(time.Second), ParseRFC3339)
+
+	// then
+	assert.Nil(t, err)
+	line, err := forward.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "2024-05-01T12:00:05Z b")
+
+	// when
+	forward, err = SeekTime(reader, reader.Size(), seekBase, ParseRFC3339)
+
+	// then
+	assert.Nil(t, err)
+	line, err = forward.Line()
+	assert.Nil(t, err)
+	assert.Equal(t, line, "header")
+}
+
+func TestSeekTime_ErrNoTimestamp(t *testing.T) {
+	// given
+	data := "a\nb\nc\n"
+	reader := strings.NewReader(data)
+
+	// when
+	_, err := SeekTime(reader, reader.Size(), seekBase, ParseRFC3339)
+
+	// then
+	assert.ErrorIs(t, err, ErrNoTimestamp)
+	var scanErr *ScanError
+	assert.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, scanErr.Op, opSeek)
+}
+
+func TestSeekTime_Errors(t *testing.T) {
+	// when
+	_, err := SeekTime(nil, 0, seekBase, ParseRFC3339)
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = SeekTime(strings.NewReader(""), -1, seekBase, ParseRFC3339)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = SeekTime(strings.NewReader(""), 0, seekBase, ParseRFC3339, WithChunkSize(0))
+
+	// then
+	assert.Equal(t, err, ErrInvalidMaxChunkSize)
+}
+
+func TestParseRFC3339(t *testing.T) {
+	// when
+	timestamp, ok := ParseRFC3339([]byte("2024-05-01T12:00:00.5+02:00 message"))
+
+	// then
+	assert.True(t, ok)
+	assert.True(t, timestamp.Equal(time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC)))
+
+	// when
+	_, ok = ParseRFC3339([]byte("  at trace"))
+
+	// then
+	assert.False(t, ok)
+}
+
+func TestParseRFC5424(t *testing.T) {
+	// when
+	timestamp, ok := ParseRFC5424([]byte("<34>1 2003-10-11T22:14:15.003Z mymachine su - ID47 - message"))
+
+	// then
+	assert.True(t, ok)
+	assert.True(t, timestamp.Equal(time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)))
+
+	for _, line := range []string{"<34>1 - mymachine su", "2003-10-11T22:14:15.003Z", "<34>1", "<>1 2003-10-11T22:14:15.003Z"} {
+		// when
+		_, ok = ParseRFC5424([]byte(line))
+
+		// then
+		assert.False(t, ok, line)
+	}
+}
+
+func TestRFC3164Parser(t *testing.T) {
+	// given
+	parse := RFC3164Parser(2024, time.UTC)
+
+	// when
+	timestamp, ok := parse([]byte("<34>Oct  1 22:14:15 mymachine su: message"))
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, timestamp, time.Date(2024, 10, 1, 22, 14, 15, 0, time.UTC))
+
+	// when
+	timestamp, ok = parse([]byte("Feb 29 00:00:00 host"))
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, timestamp, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+
+	// when
+	_, ok = parse([]byte("Oct 11"))
+
+	// then
+	assert.False(t, ok)
+}
+
+func TestParseCommonLog(t *testing.T) {
+	// when
+	timestamp, ok := ParseCommonLog([]byte(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`))
+
+	// then
+	assert.True(t, ok)
+	assert.True(t, timestamp.Equal(time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC)))
+
+	for _, line := range []string{"127.0.0.1 - frank", "[10/Oct/2000", "[today]"} {
+		// when
+		_, ok = ParseCommonLog([]byte(line))
+
+		// then
+		assert.False(t, ok, line)
+	}
+}
@@ -0,0 +1,99 @@
+package linescanner
+
+import (
+	"io"
+)
+
+// Tail returns the last n lines of the first size bytes of reader in file order.
+// A delimiter at the very end does not start another line.
+func Tail(reader io.ReaderAt, size int64, n int, opts ...Option) ([]string, error) {
+	var lines []string
+	err := tail(reader, size, n, opts, func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// TailTo writes the last n lines to w in file order, each followed by the delimiter.
+func TailTo(w io.Writer, reader io.ReaderAt, size int64, n int, opts ...Option) error {
+	delimiter := newOptions(opts).delimiter
+	return tail(reader, size, n, opts, func(line []byte) error {
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		_, err := w.Write(delimiter)
+		return err
+	})
+}
+
+func tail(reader io.ReaderAt, size int64, n int, opts []Option, fn func(line []byte) error) error {
+	if reader == nil {
+		return ErrNilReader
+	}
+	if size < 0 {
+		return ErrInvalidPosition
+	}
+	if n < 0 {
+		return ErrInvalidLineCount
+	}
+	reader = io.NewSectionReader(reader, 0, size)
+	start, count, err := tailStart(reader, size, n, opts)
+	if err != nil {
+		return err
+	}
+	forward, err := newForward(reader, start, opts)
+	if err != nil {
+		return err
+	}
+	defer forward.Close()
+	for count > 0 {
+		line, err := forward.LineBytes()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if !forward.info.Continued {
+			count--
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return nil
+}
+
+func tailStart(reader io.ReaderAt, size int64, n int, opts []Option) (int64, int, error) {
+	if n == 0 {
+		return size, 0, nil
+	}
+	backward, err := newBackward(reader, size, opts)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer backward.Close()
+	start, count := size, 0
+	for first := true; count < n; first = false {
+		_, err := backward.LineBytes()
+		if err != nil && err != io.EOF {
+			return 0, 0, err
+		}
+		if backward.noLine {
+			break
+		}
+		trailing := first && err == nil && backward.info.Start == size
+		if !trailing && !backward.info.Continued {
+			start = backward.info.Start
+			count++
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return start, count, nil
+}
@@ -0,0 +1,143 @@
+package linescanner
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestTail(t *testing.T) {
+	// given
+	tests := []struct {
+		data  string
+		n     int
+		lines []string
+	}{
+		{"ab\ncd\nef", 2, []string{"cd", "ef"}},
+		{"ab\ncd\nef\n", 2, []string{"cd", "ef"}},
+		{"ab\r\ncd\r\n", 1, []string{"cd"}},
+		{"ab\ncd\n", 5, []string{"ab", "cd"}},
+		{"ab\n\n", 2, []string{"ab", ""}},
+		{"\n", 1, []string{""}},
+		{"\n\nab", 3, []string{"", "", "ab"}},
+		{"ab\ncd", 0, nil},
+		{"", 3, nil},
+	}
+
+	for _, test := range tests {
+		// when
+		lines, err := Tail(strings.NewReader(test.data), int64(len(test.data)), test.n, WithChunkSize(2), WithMaxBufferSize(6))
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, lines, test.lines, "%q", test.data)
+	}
+}
+
+func TestTail_Size(t *testing.T) {
+	// given
+	data := "ab\ncd\nef\ngh"
+
+	// when
+	lines, err := Tail(strings.NewReader(data), 6, 2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"ab", "cd"})
+}
+
+func TestTail_Delimiter(t *testing.T) {
+	// given
+	data := "ab\x00cd\x00ef\x00"
+
+	// when
+	lines, err := Tail(strings.NewReader(data), int64(len(data)), 2, WithDelimiter([]byte{0}))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"cd", "ef"})
+}
+
+func TestTail_LongLine(t *testing.T) {
+	// given
+	data := "ab\nabcdefghij\ncd\n"
+
+	// when
+	lines, err := Tail(strings.NewReader(data), int64(len(data)), 2, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineFragment))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"abc", "def", "ghi", "j", "cd"})
+
+	// when
+	lines, err = Tail(strings.NewReader(data), int64(len(data)), 2, WithChunkSize(2), WithMaxBufferSize(4), WithLongLinePolicy(LongLineSkip))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, lines, []string{"ab", "cd"})
+}
+
+func TestTail_Errors(t *testing.T) {
+	// given
+	reader := strings.NewReader("abcdef\n")
+
+	// when
+	_, err := Tail(nil, 0, 1)
+
+	// then
+	assert.Equal(t, err, ErrNilReader)
+
+	// when
+	_, err = Tail(reader, -1, 1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidPosition)
+
+	// when
+	_, err = Tail(reader, reader.Size(), -1)
+
+	// then
+	assert.Equal(t, err, ErrInvalidLineCount)
+
+	// when
+	_, err = Tail(reader, reader.Size(), 1, WithChunkSize(2), WithMaxBufferSize(4))
+
+	// then
+	assert.ErrorIs(t, err, ErrBufferOverflow)
+}
+
+func TestTailTo(t *testing.T) {
+	// given
+	data := "ab\r\ncd\nef"
+	var buffer bytes.Buffer
+
+	// when
+	err := TailTo(&buffer, strings.NewReader(data), int64(len(data)), 2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, buffer.String(), "cd\nef\n")
+}
+
+func TestTailTo_WriteError(t *testing.T) {
+	// given
+	data := "ab\ncd\n"
+	writeErr := errors.New("write error")
+
+	// when
+	err := TailTo(&failingWriter{err: writeErr}, strings.NewReader(data), int64(len(data)), 2)
+
+	// then
+	assert.Equal(t, err, writeErr)
+}
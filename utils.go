@@ -1,5 +1,10 @@
 package linescanner
 
+import (
+	"io"
+	"os"
+)
+
 func minInt(x int, y int) int {
 	if x < y {
 		return x
@@ -7,6 +12,13 @@ func minInt(x int, y int) int {
 	return y
 }
 
+func minInt64(x int64, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
 func maxInt(x int, y int) int {
 	if x > y {
 		return x
@@ -14,9 +26,86 @@ func maxInt(x int, y int) int {
 	return y
 }
 
+func maxInt64(x int64, y int64) int64 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+// growChunkSize returns the adaptive size of the read after one of size chunkSize.
+func growChunkSize(chunkSize int, maxChunkSize int) int {
+	return minInt(chunkSize*2, maxChunkSize)
+}
+
+// trailingLine reports whether line is the empty remainder after a final delimiter, which is not a line.
+func trailingLine(line []byte, info LineInfo) bool {
+	return len(line) == 0 && !info.Terminated && !info.CarriageReturn
+}
+
 func removeCarriageReturn(line []byte) string {
+	return string(trimCarriageReturn(line))
+}
+
+func trimCarriageReturn(line []byte) []byte {
 	if len(line) > 0 && line[len(line)-1] == '\r' {
-		return string(line[:len(line)-1])
+		return line[:len(line)-1]
+	}
+	return line
+}
+
+func lineEnd(info LineInfo) int64 {
+	if info.CarriageReturn {
+		return info.End + 1
+	}
+	return info.End
+}
+
+func readerSize(reader io.ReaderAt) (int64, bool) {
+	switch r := reader.(type) {
+	case interface{ Size() int64 }:
+		return r.Size(), true
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		return info.Size(), true
+	}
+	return 0, false
+}
+
+func validatePosition(reader io.ReaderAt, position int64) error {
+	if position == endPosition {
+		return nil
+	}
+	if position < 0 {
+		return ErrInvalidPosition
+	}
+	if size, ok := readerSize(reader); ok && position > size {
+		return ErrInvalidPosition
+	}
+	return nil
+}
+
+func tokenOffset(data []byte, token []byte) (int, bool) {
+	if len(token) == 0 || cap(token) > cap(data) {
+		return 0, false
+	}
+	offset := cap(data) - cap(token)
+	if offset+len(token) > len(data) || &data[offset] != &token[0] {
+		return 0, false
+	}
+	return offset, true
+}
+
+func readFullAt(reader io.ReaderAt, p []byte, off int64) error {
+	n, err := reader.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		return io.ErrUnexpectedEOF
 	}
-	return string(line)
+	return err
 }
@@ -1,10 +1,31 @@
 package linescanner
 
 import (
-	"github.com/stretchr/testify/assert"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
+// formatLines formats n lines of test data with format. The arguments of line i are args(i), or i
+// if args is nil.
+func formatLines(n int, format string, args func(i int) []any) string {
+	var builder strings.Builder
+	for i := 0; i < n; i++ {
+		if args == nil {
+			fmt.Fprintf(&builder, format, i)
+		} else {
+			fmt.Fprintf(&builder, format, args(i)...)
+		}
+	}
+	return builder.String()
+}
+
 func TestMinInt(t *testing.T) {
 	// case 1
 	x, y := 2, 1
@@ -21,6 +42,17 @@ func TestMinInt(t *testing.T) {
 	assert.Equal(t, min, 1)
 }
 
+func TestMinInt64(t *testing.T) {
+	// case 1
+	x, y := int64(1<<40), int64(1)
+	min := minInt64(x, y)
+	assert.Equal(t, min, int64(1))
+
+	// case 2
+	min = minInt64(y, x)
+	assert.Equal(t, min, int64(1))
+}
+
 func TestMaxInt(t *testing.T) {
 	// case 1
 	x, y := 2, 1
@@ -69,3 +101,145 @@ func TestRemoveCarrageReturn_EmptyLine(t *testing.T) {
 	// then
 	assert.Empty(t, lineStr)
 }
+
+func TestTrimCarriageReturn(t *testing.T) {
+	// given
+	line := []byte("abcd\r")
+
+	// when
+	trimmed := trimCarriageReturn(line)
+
+	// then
+	assert.Equal(t, trimmed, []byte("abcd"))
+	assert.Equal(t, &trimmed[0], &line[0])
+}
+
+func TestTrimCarriageReturn_NoCarriageReturn(t *testing.T) {
+	// given
+	line := []byte("abcd")
+
+	// when
+	trimmed := trimCarriageReturn(line)
+
+	// then
+	assert.Equal(t, trimmed, line)
+}
+
+func TestReaderSize(t *testing.T) {
+	// case 1
+	size, ok := readerSize(strings.NewReader("abcd"))
+	assert.True(t, ok)
+	assert.Equal(t, size, int64(4))
+
+	// case 2
+	size, ok = readerSize(bytes.NewReader([]byte("ab")))
+	assert.True(t, ok)
+	assert.Equal(t, size, int64(2))
+
+	// case 3
+	_, ok = readerSize(new(ReaderMock))
+	assert.False(t, ok)
+}
+
+func TestReaderSize_File(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "file")
+	assert.Nil(t, os.WriteFile(path, []byte("abc\n"), 0o644))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	// when
+	size, ok := readerSize(file)
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, size, int64(4))
+}
+
+func TestReaderSize_Directory(t *testing.T) {
+	// given
+	dir, err := os.Open(t.TempDir())
+	assert.Nil(t, err)
+	defer dir.Close()
+
+	// when
+	_, ok := readerSize(dir)
+
+	// then
+	assert.False(t, ok)
+}
+
+func TestValidatePosition(t *testing.T) {
+	// given
+	reader := strings.NewReader("abcd")
+
+	// then
+	assert.Nil(t, validatePosition(reader, 0))
+	assert.Nil(t, validatePosition(reader, 4))
+	assert.Nil(t, validatePosition(reader, endPosition))
+	assert.Equal(t, validatePosition(reader, 5), ErrInvalidPosition)
+	assert.Equal(t, validatePosition(reader, -2), ErrInvalidPosition)
+	assert.Nil(t, validatePosition(new(ReaderMock), 100))
+	assert.Nil(t, validatePosition(newSparseReader(sparseOffset, "ab"), sparseOffset+2))
+	assert.Equal(t, validatePosition(newSparseReader(sparseOffset, "ab"), sparseOffset+3), ErrInvalidPosition)
+}
+
+func TestTokenOffset(t *testing.T) {
+	// given
+	data := []byte("  ab c")
+
+	// when
+	offset, ok := tokenOffset(data, data[2:4])
+
+	// then
+	assert.True(t, ok)
+	assert.Equal(t, offset, 2)
+
+	// when
+	_, ok = tokenOffset(data, []byte("ab"))
+
+	// then
+	assert.False(t, ok)
+
+	// when
+	_, ok = tokenOffset(data, nil)
+
+	// then
+	assert.False(t, ok)
+}
+
+const sparseOffset = int64(1)<<32 + 5
+
+// sparseReader reads as zeros except for data stored at offset, so it can stand in for a huge file.
+type sparseReader struct {
+	offset int64
+	data   []byte
+}
+
+func newSparseReader(offset int64, data string) *sparseReader {
+	return &sparseReader{offset: offset, data: []byte(data)}
+}
+
+func (r *sparseReader) Size() int64 {
+	return r.offset + int64(len(r.data))
+}
+
+func (r *sparseReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.Size() {
+		return 0, io.EOF
+	}
+	n := 0
+	for ; n < len(p) && off+int64(n) < r.Size(); n++ {
+		pos := off + int64(n)
+		if pos < r.offset {
+			p[n] = 0
+		} else {
+			p[n] = r.data[pos-r.offset]
+		}
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}